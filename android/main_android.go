@@ -18,9 +18,20 @@ func init() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	// there is no exit on Android, the app just gets killed, so the games are autosaved as they are played,
+	// and when the app is paused (see Suspend)
+	if savedGames := sol.LoadSavedGames(); savedGames != nil {
+		sol.TheBaize.SetSavedGames(savedGames)
+	}
 	mobile.SetGame(game)
 }
 
+// Suspend saves the games. The activity calls it from onPause, before suspending the game view,
+// as the game loop doesn't run while the app is in the background, where it may be killed.
+func Suspend() {
+	sol.Suspend()
+}
+
 // Dummy is a dummy exported function.
 //
 // gomobile doesn't compile a package that doesn't include any exported function.
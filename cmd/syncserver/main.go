@@ -0,0 +1,23 @@
+// syncserver is the reference key/value store for syncing statistics and preferences between devices.
+//
+//	$ go run ./cmd/syncserver -addr :8080 -dir /var/lib/gosol
+//
+// then set SyncURL in each device's preferences.json to eg "http://myserver:8080/alice/"
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"oddstream.games/gosol/remote"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "address to listen on")
+	dir := flag.String("dir", "", "directory to keep values in (if empty, they are forgotten on exit)")
+	flag.Parse()
+
+	log.Printf("listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, remote.NewServer(*dir)))
+}
@@ -0,0 +1,82 @@
+package input
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"oddstream.games/gosol/util"
+)
+
+const (
+	gamepadDeadZone    = 0.2  // ignore small stick movements
+	gamepadCursorSpeed = 12.0 // pixels per tick at full stick deflection
+)
+
+// GamepadCursor is a virtual pointer moved by the left stick of a standard layout gamepad,
+// so that a gamepad can tap and drag like a mouse
+type GamepadCursor struct {
+	ID      ebiten.GamepadID // the gamepad that last moved the cursor
+	x, y    float64
+	visible bool
+}
+
+// TheGamepadCursor is the virtual pointer shared by all connected gamepads
+var TheGamepadCursor = &GamepadCursor{}
+
+// GamepadStrokeButton is the standard layout button that starts a stroke while the gamepad cursor is visible;
+// press it to tap, hold it while moving the stick to drag
+var GamepadStrokeButton = ebiten.StandardGamepadButtonRightBottom
+
+var allGamepadIDs, gamepadIDs []ebiten.GamepadID // reused each tick to avoid allocations
+
+// StandardGamepads returns the connected gamepads that have a standard layout mapping
+func StandardGamepads() []ebiten.GamepadID {
+	allGamepadIDs = ebiten.AppendGamepadIDs(allGamepadIDs[:0])
+	gamepadIDs = gamepadIDs[:0]
+	for _, id := range allGamepadIDs {
+		if ebiten.IsStandardGamepadLayoutAvailable(id) {
+			gamepadIDs = append(gamepadIDs, id)
+		}
+	}
+	return gamepadIDs
+}
+
+// Update moves the cursor with the left stick of any gamepad, keeping it inside width, height.
+// The cursor appears when a stick is moved.
+func (gc *GamepadCursor) Update(width, height int) {
+	for _, id := range StandardGamepads() {
+		dx := ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickHorizontal)
+		dy := ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickVertical)
+		if dx > -gamepadDeadZone && dx < gamepadDeadZone {
+			dx = 0
+		}
+		if dy > -gamepadDeadZone && dy < gamepadDeadZone {
+			dy = 0
+		}
+		if dx == 0 && dy == 0 {
+			continue
+		}
+		if !gc.visible && gc.x == 0 && gc.y == 0 {
+			// first appearance, start in the middle of the screen
+			gc.x, gc.y = float64(width)/2, float64(height)/2
+		}
+		gc.ID = id
+		gc.visible = true
+		gc.x = util.Clamp(gc.x+dx*gamepadCursorSpeed, 0, float64(width-1))
+		gc.y = util.Clamp(gc.y+dy*gamepadCursorSpeed, 0, float64(height-1))
+		return
+	}
+}
+
+// Visible returns true if the cursor is being used
+func (gc *GamepadCursor) Visible() bool {
+	return gc.visible
+}
+
+// Hide the cursor, for example when the mouse or the gamepad's d-pad is used instead
+func (gc *GamepadCursor) Hide() {
+	gc.visible = false
+}
+
+// Position returns the x,y coordinates of the cursor
+func (gc *GamepadCursor) Position() (int, int) {
+	return int(gc.x), int(gc.y)
+}
@@ -0,0 +1,137 @@
+package input
+
+import (
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// GestureType is the kind of multi-touch gesture that has been recognized
+type GestureType int
+
+const (
+	GestureBegin   GestureType = iota + 1 // a second finger has touched; any single finger stroke should be abandoned
+	Pinch                                 // two fingers are moving apart or together
+	PinchEnd                              // the pinch has finished
+	TwoFingerTap                          // two fingers touched and lifted without moving
+	ThreeFingerTap                        // three fingers touched and lifted without moving
+)
+
+const (
+	gestureTapSlop   = 16  // fingers moving less than this many pixels are tapping, not pinching
+	gestureTapMillis = 300 // fingers held longer than this are not tapping
+)
+
+// GestureEvent is sent to a GestureObserver when a multi-touch gesture is recognized
+type GestureEvent struct {
+	Type  GestureType
+	Scale float64 // for Pinch and PinchEnd, the distance between the fingers relative to when the pinch started
+	X, Y  int     // for Pinch and PinchEnd, the point midway between the fingers
+}
+
+// GestureObserver is notified when a multi-touch gesture is recognized
+type GestureObserver interface {
+	NotifyGesture(GestureEvent)
+}
+
+// GestureRecognizer watches all the touches, and recognizes gestures made with two or more fingers.
+// Single finger touches are left alone, to be handled by Stroke.
+type GestureRecognizer struct {
+	active     bool
+	pinching   bool
+	moved      bool // at least one finger has moved further than a tap would
+	maxTouches int
+	timeStart  time.Time
+	pinchIDs   [2]ebiten.TouchID
+	startDist  float64
+	lastScale  float64
+	lastX      int
+	lastY      int
+	starts     map[ebiten.TouchID][2]int
+	ids        []ebiten.TouchID
+}
+
+// MultiTouch returns true if more than one finger is touching the screen
+func MultiTouch() bool {
+	return len(ebiten.AppendTouchIDs(nil)) > 1
+}
+
+func (g *GestureRecognizer) pinchGeometry() (dist float64, cx, cy int) {
+	x0, y0 := ebiten.TouchPosition(g.pinchIDs[0])
+	x1, y1 := ebiten.TouchPosition(g.pinchIDs[1])
+	dist = math.Hypot(float64(x1-x0), float64(y1-y0))
+	return dist, (x0 + x1) / 2, (y0 + y1) / 2
+}
+
+func (g *GestureRecognizer) touching(id ebiten.TouchID) bool {
+	for _, i := range g.ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Update is called once per frame, and notifies the observer of any gestures
+func (g *GestureRecognizer) Update(observer GestureObserver) {
+	g.ids = ebiten.AppendTouchIDs(g.ids[:0])
+
+	if !g.active {
+		if len(g.ids) < 2 {
+			return
+		}
+		g.active = true
+		g.pinching = false
+		g.moved = false
+		g.maxTouches = 0
+		g.timeStart = time.Now()
+		g.starts = map[ebiten.TouchID][2]int{}
+		g.pinchIDs = [2]ebiten.TouchID{g.ids[0], g.ids[1]}
+		g.startDist, _, _ = g.pinchGeometry()
+		observer.NotifyGesture(GestureEvent{Type: GestureBegin})
+	}
+
+	if len(g.ids) > g.maxTouches {
+		g.maxTouches = len(g.ids)
+	}
+	for _, id := range g.ids {
+		x, y := ebiten.TouchPosition(id)
+		if start, ok := g.starts[id]; !ok {
+			g.starts[id] = [2]int{x, y}
+		} else if math.Abs(float64(x-start[0])) > gestureTapSlop || math.Abs(float64(y-start[1])) > gestureTapSlop {
+			g.moved = true
+		}
+	}
+
+	if g.touching(g.pinchIDs[0]) && g.touching(g.pinchIDs[1]) {
+		dist, cx, cy := g.pinchGeometry()
+		if !g.pinching && g.maxTouches == 2 && math.Abs(dist-g.startDist) > gestureTapSlop {
+			g.pinching = true
+		}
+		if g.pinching && g.startDist > 0 {
+			g.lastScale, g.lastX, g.lastY = dist/g.startDist, cx, cy
+			observer.NotifyGesture(GestureEvent{Type: Pinch, Scale: g.lastScale, X: cx, Y: cy})
+		}
+	} else if g.pinching {
+		// one of the pinching fingers has lifted
+		g.pinching = false
+		observer.NotifyGesture(GestureEvent{Type: PinchEnd, Scale: g.lastScale, X: g.lastX, Y: g.lastY})
+	}
+
+	if len(g.ids) > 0 {
+		return
+	}
+
+	// all fingers have lifted
+	g.active = false
+	if g.moved || g.pinching || time.Since(g.timeStart).Milliseconds() > gestureTapMillis {
+		return
+	}
+	switch g.maxTouches {
+	case 2:
+		observer.NotifyGesture(GestureEvent{Type: TwoFingerTap})
+	case 3:
+		observer.NotifyGesture(GestureEvent{Type: ThreeFingerTap})
+	}
+}
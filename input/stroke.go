@@ -32,16 +32,18 @@ type StrokeSource interface {
 }
 
 // MouseStrokeSource is a StrokeSource implementation of mouse.
-type MouseStrokeSource struct{}
+type MouseStrokeSource struct {
+	Button ebiten.MouseButton // zero value is the left button
+}
 
 // Position returns the x,y cordinates of the cursor position
 func (m *MouseStrokeSource) Position() (int, int) {
 	return ebiten.CursorPosition()
 }
 
-// IsJustReleased returns true if the left mouse button was released in the current frame
+// IsJustReleased returns true if the mouse button was released in the current frame
 func (m *MouseStrokeSource) IsJustReleased() bool {
-	return inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft)
+	return inpututil.IsMouseButtonJustReleased(m.Button)
 }
 
 // TouchStrokeSource is a StrokeSource implementation of touch.
@@ -59,6 +61,22 @@ func (t *TouchStrokeSource) IsJustReleased() bool {
 	return inpututil.IsTouchJustReleased(t.ID)
 }
 
+// GamepadStrokeSource is a StrokeSource implementation of a gamepad, using the virtual gamepad cursor.
+type GamepadStrokeSource struct {
+	ID     ebiten.GamepadID
+	Button ebiten.StandardGamepadButton
+}
+
+// Position returns the x,y cordinates of the gamepad cursor position
+func (g *GamepadStrokeSource) Position() (int, int) {
+	return TheGamepadCursor.Position()
+}
+
+// IsJustReleased returns true if the gamepad button was released in the current frame
+func (g *GamepadStrokeSource) IsJustReleased() bool {
+	return inpututil.IsStandardGamepadButtonJustReleased(g.ID, g.Button)
+}
+
 // Stroke manages the current drag state by mouse.
 type Stroke struct {
 	source StrokeSource
@@ -71,6 +89,14 @@ type Stroke struct {
 
 	timeStart time.Time
 
+	// longPressMillis is how long the stroke must stay still before a LongPress event is sent,
+	// or -1 if holding still never makes a long press
+	longPressMillis int64
+	slop            int  // how far the stroke can move and still become a long press
+	strayed         bool // the stroke has moved too far to be a long press
+	longPressed     bool // a LongPress event has been sent
+	peekOnly        bool // the stroke only ever long presses; it never moves, drags or taps
+
 	released  bool
 	cancelled bool
 
@@ -89,6 +115,16 @@ const (
 	Tap
 	Stop
 	Cancel
+	LongPress
+)
+
+// DefaultLongPressMillis is how long a stroke has to be held still to become a long press
+const DefaultLongPressMillis = 500
+
+// A fingertip wobbles more than a mouse, so a touch can move further and still be held still
+const (
+	defaultSlop = 2
+	touchSlop   = 12
 )
 
 // StrokeEvent is sent to observers when stroke moves or ends
@@ -99,9 +135,16 @@ type StrokeEvent struct {
 	X, Y   int
 }
 
-// NewStroke create a new Stroke object
+// NewStroke create a new Stroke object; only touches become long presses by being held still,
+// as a mouse player may well pause on a card before dragging it
 func NewStroke(source StrokeSource) *Stroke {
 	x, y := source.Position()
+	var longPressMillis int64 = -1
+	slop := defaultSlop
+	if _, ok := source.(*TouchStrokeSource); ok {
+		longPressMillis = DefaultLongPressMillis
+		slop = touchSlop
+	}
 	return &Stroke{
 		source:    source,
 		initX:     x,
@@ -109,6 +152,9 @@ func NewStroke(source StrokeSource) *Stroke {
 		currX:     x,
 		currY:     y,
 		timeStart: time.Now(),
+
+		longPressMillis: longPressMillis,
+		slop:            slop,
 	}
 }
 
@@ -118,15 +164,34 @@ func StartStroke(observer Observer) *Stroke {
 	// Stroke always starts immediately otherwise weird lag (tap will cancel drag)
 	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
 		s = NewStroke(&MouseStrokeSource{})
+		TheGamepadCursor.Hide()
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+		// holding the right button is the same as a long press, without the wait,
+		// but it only ever peeks: it never drags or taps what it is held on
+		s = NewStroke(&MouseStrokeSource{Button: ebiten.MouseButtonRight})
+		s.longPressMillis = 0
+		s.peekOnly = true
+		TheGamepadCursor.Hide()
 	}
 	ids := inpututil.JustPressedTouchIDs()
-	if len(ids) > 0 {
+	if len(ids) > 0 && !MultiTouch() {
+		// extra fingers are left to the GestureRecognizer
 		// println(len(ids), "touch IDs, first is", ids[0])
 		s = NewStroke(&TouchStrokeSource{ID: ids[0]})
+		TheGamepadCursor.Hide()
+	}
+	if TheGamepadCursor.Visible() && inpututil.IsStandardGamepadButtonJustPressed(TheGamepadCursor.ID, GamepadStrokeButton) {
+		s = NewStroke(&GamepadStrokeSource{ID: TheGamepadCursor.ID, Button: GamepadStrokeButton})
 	}
 	if s != nil {
 		s.Add(observer)
 		s.Notify(StrokeEvent{Event: Start, Stroke: s, X: s.initX, Y: s.initY})
+		if s.peekOnly {
+			// the button may be released before the next Update
+			s.longPressed = true
+			s.Notify(StrokeEvent{Event: LongPress, Stroke: s, Object: s.draggedObject, X: s.initX, Y: s.initY})
+		}
 	}
 	return s
 }
@@ -147,8 +212,11 @@ func (s *Stroke) Update() {
 		// can't use distance < n because card will be animating
 
 		// send a tap event *before* sending a stroke cancel event, as the latter will cause owner to dispose of the stroke
-		if util.Abs(s.initX-s.currX) < 2 && util.Abs(s.initY-s.currY) < 2 {
-			if elapsed < 200 {
+		// a long press has already been dealt with, so it never becomes a tap
+		if s.peekOnly {
+			s.Notify(StrokeEvent{Event: Cancel, Stroke: s, Object: s.draggedObject, X: s.currX, Y: s.currY})
+		} else if util.Abs(s.initX-s.currX) < 2 && util.Abs(s.initY-s.currY) < 2 {
+			if elapsed < 200 && !s.longPressed {
 				// println("Stroke.Update() sending a tap event")
 				s.Notify(StrokeEvent{Event: Tap, Stroke: s, Object: s.draggedObject, X: s.currX, Y: s.currY})
 				s.Notify(StrokeEvent{Event: Cancel, Stroke: s, Object: s.draggedObject, X: s.currX, Y: s.currY})
@@ -160,10 +228,18 @@ func (s *Stroke) Update() {
 		}
 	} else {
 		x, y := s.source.Position()
-		if s.currX != x || s.currY != y {
+		// a peek keeps still whatever was pressed on
+		if !s.peekOnly && (s.currX != x || s.currY != y) {
 			s.currX, s.currY = x, y
+			if util.Abs(s.initX-s.currX) >= s.slop || util.Abs(s.initY-s.currY) >= s.slop {
+				s.strayed = true
+			}
 			s.Notify(StrokeEvent{Event: Move, Stroke: s, Object: s.draggedObject, X: s.currX, Y: s.currY})
 		}
+		if !s.strayed && !s.longPressed && s.longPressMillis >= 0 && time.Since(s.timeStart).Milliseconds() >= s.longPressMillis {
+			s.longPressed = true
+			s.Notify(StrokeEvent{Event: LongPress, Stroke: s, Object: s.draggedObject, X: s.currX, Y: s.currY})
+		}
 	}
 
 }
@@ -173,6 +249,16 @@ func (s *Stroke) Cancel() {
 	s.cancelled = true
 }
 
+// Interrupt cancels this stroke on behalf of the user (eg when a multi-touch gesture starts),
+// telling observers so that any dragged object can be put back
+func (s *Stroke) Interrupt() {
+	if s.released || s.cancelled {
+		return
+	}
+	s.Notify(StrokeEvent{Event: Cancel, Stroke: s, Object: s.draggedObject, X: s.currX, Y: s.currY})
+	s.cancelled = true
+}
+
 // IsReleased returns true if ...
 func (s *Stroke) IsReleased() bool {
 	return s.released
@@ -36,6 +36,7 @@ func main() {
 	flag.BoolVar(&sol.NoShuffle, "noshuf", false, "do not shuffle cards")
 	flag.BoolVar(&sol.NoScrunch, "noscrunch", false, "do not scrunch cards")
 	flag.BoolVar(&ui.GenerateIcons, "generateicons", false, "generate icon files")
+	flag.StringVar(&sol.StorageDir, "dir", "", "keep preferences, statistics and saved games in this directory")
 
 	flag.Parse()
 
@@ -68,8 +69,8 @@ func main() {
 	}
 
 	if !sol.NoGameLoad {
-		if undoStack := sol.LoadUndoStack(); undoStack != nil {
-			sol.TheBaize.SetUndoStack(undoStack)
+		if savedGames := sol.LoadSavedGames(); savedGames != nil {
+			sol.TheBaize.SetSavedGames(savedGames)
 		}
 	}
 
@@ -7,6 +7,7 @@ package main
 
 import (
 	"log"
+	"syscall/js"
 
 	// load png decoder in main package
 	_ "image/png"
@@ -22,6 +23,26 @@ func main() {
 		log.Fatal(err)
 	}
 
+	if !sol.NoGameLoad {
+		if savedGames := sol.LoadSavedGames(); savedGames != nil {
+			sol.TheBaize.SetSavedGames(savedGames)
+		}
+	}
+
+	// the game loop stops while the tab is hidden, and a hidden tab may be closed or discarded without warning,
+	// so the games are saved as soon as the tab is hidden, or the page goes away
+	suspend := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if args[0].Get("type").String() == "pagehide" || js.Global().Get("document").Get("visibilityState").String() == "hidden" {
+			sol.Suspend()
+		}
+		return nil
+	})
+	js.Global().Get("document").Call("addEventListener", "visibilitychange", suspend)
+	js.Global().Call("addEventListener", "pagehide", suspend)
+
+	// this rarely runs, as closing the browser tab just stops everything;
+	// the games are autosaved as they are played, and when the tab is hidden
+
 	defer func() {
 		println("main defer cleanup")
 		if !sol.NoGameSave {
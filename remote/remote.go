@@ -0,0 +1,160 @@
+// Package remote syncs keys with a simple key/value store over HTTP, for package sol.
+//
+// The contract is plain REST: GET, PUT and DELETE on BaseURL plus the key. GET returns
+// 404 if the key has never been saved. Each value has an ETag, and a PUT with If-Match
+// (or If-None-Match: * for a new key) fails with 412 if someone else got there first.
+// Server in this package is a reference implementation.
+package remote
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrConflict is returned by Put when the value has changed since it was read
+var ErrConflict = errors.New("remote value has changed")
+
+// Client talks to a key/value store
+type Client struct {
+	BaseURL string // eg "https://example.com/kv/alice/"; keys are appended to it
+	HTTP    *http.Client
+}
+
+// NewClient creates a Client with a short timeout, as syncing should never hold up a game for long
+func NewClient(baseURL string) *Client {
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	return &Client{BaseURL: baseURL, HTTP: &http.Client{Timeout: 5 * time.Second}}
+}
+
+// Get returns the value of key and its ETag, or nil and an empty ETag if key does not exist
+func (c *Client) Get(key string) ([]byte, string, error) {
+	resp, err := c.HTTP.Get(c.BaseURL + key)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		value, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, "", err
+		}
+		return value, resp.Header.Get("ETag"), nil
+	case http.StatusNotFound:
+		return nil, "", nil
+	default:
+		return nil, "", fmt.Errorf("GET %s: %s", key, resp.Status)
+	}
+}
+
+// Put replaces the value of key. If etag is not empty, the value must not have changed since
+// it was read with that ETag; "*" means the key must not exist yet. ErrConflict is returned if it has.
+func (c *Client) Put(key string, value []byte, etag string) error {
+	req, err := http.NewRequest(http.MethodPut, c.BaseURL+key, bytes.NewReader(value))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	switch etag {
+	case "":
+	case "*":
+		req.Header.Set("If-None-Match", "*")
+	default:
+		req.Header.Set("If-Match", etag)
+	}
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		return nil
+	case http.StatusPreconditionFailed:
+		return ErrConflict
+	default:
+		return fmt.Errorf("PUT %s: %s", key, resp.Status)
+	}
+}
+
+// DeviceEntry is one device's own copy of some data; only that device ever changes it,
+// and it bumps Revision each time it does
+type DeviceEntry struct {
+	Revision int
+	Data     json.RawMessage
+}
+
+// DeviceMap holds each device's entry, by device ID. Merging two DeviceMaps keeps the newest
+// entry for each device, which gives the same result whatever order merges happen in,
+// so devices can sync with no conflicts to resolve
+type DeviceMap map[string]*DeviceEntry
+
+// Merge takes any newer entries from other
+func (m DeviceMap) Merge(other DeviceMap) {
+	for id, e := range other {
+		if e == nil {
+			continue
+		}
+		if mine, ok := m[id]; !ok || mine.Revision < e.Revision {
+			m[id] = e
+		}
+	}
+}
+
+// covers returns true if m has every entry of other, at the same revision or newer
+func (m DeviceMap) covers(other DeviceMap) bool {
+	for id, e := range other {
+		if mine, ok := m[id]; !ok || (e != nil && mine.Revision < e.Revision) {
+			return false
+		}
+	}
+	return true
+}
+
+// maxSyncAttempts is how many times SyncDevices will try again after losing a race with another device
+const maxSyncAttempts = 4
+
+// SyncDevices merges local with the DeviceMap stored remotely under key, stores the result
+// if that changes anything, and returns it
+func (c *Client) SyncDevices(key string, local DeviceMap) (DeviceMap, error) {
+	for attempt := 0; attempt < maxSyncAttempts; attempt++ {
+		value, etag, err := c.Get(key)
+		if err != nil {
+			return nil, err
+		}
+		remote := DeviceMap{}
+		if value != nil {
+			if err := json.Unmarshal(value, &remote); err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+		} else {
+			etag = "*"
+		}
+		merged := DeviceMap{}
+		merged.Merge(remote)
+		merged.Merge(local)
+		if value != nil && remote.covers(merged) {
+			return merged, nil // nothing new to tell the store
+		}
+		value, err = json.Marshal(merged)
+		if err != nil {
+			return nil, err
+		}
+		err = c.Put(key, value, etag)
+		if err == nil {
+			return merged, nil
+		}
+		if !errors.Is(err, ErrConflict) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("%s: %w", key, ErrConflict)
+}
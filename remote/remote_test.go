@@ -0,0 +1,119 @@
+package remote
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func newTestClient(t *testing.T, dir string) *Client {
+	ts := httptest.NewServer(NewServer(dir))
+	t.Cleanup(ts.Close)
+	return NewClient(ts.URL + "/test/")
+}
+
+func TestGetPut(t *testing.T) {
+	c := newTestClient(t, "")
+
+	value, etag, err := c.Get("missing")
+	if err != nil || value != nil || etag != "" {
+		t.Fatalf("missing key: got %q %q %v", value, etag, err)
+	}
+
+	if err := c.Put("k", []byte(`"one"`), "*"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Put("k", []byte(`"two"`), "*"); !errors.Is(err, ErrConflict) {
+		t.Errorf("creating an existing key: want ErrConflict, got %v", err)
+	}
+	value, etag, err = c.Get("k")
+	if err != nil || string(value) != `"one"` {
+		t.Fatalf("got %q %v", value, err)
+	}
+	if err := c.Put("k", []byte(`"three"`), etag); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Put("k", []byte(`"four"`), etag); !errors.Is(err, ErrConflict) {
+		t.Errorf("stale ETag: want ErrConflict, got %v", err)
+	}
+	if err := c.Put("../escape", []byte(`""`), ""); err == nil {
+		t.Error("bad key was accepted")
+	}
+}
+
+func TestServerDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := newTestClient(t, dir).Put("k", []byte(`"kept"`), ""); err != nil {
+		t.Fatal(err)
+	}
+	// a new server reading the same directory
+	value, _, err := newTestClient(t, dir).Get("k")
+	if err != nil || string(value) != `"kept"` {
+		t.Errorf("got %q %v", value, err)
+	}
+}
+
+func entry(revision int, won int) *DeviceEntry {
+	return &DeviceEntry{Revision: revision, Data: json.RawMessage(fmt.Sprintf(`{"Won":%d}`, won))}
+}
+
+func TestMerge(t *testing.T) {
+	a := DeviceMap{"phone": entry(2, 5), "laptop": entry(1, 1)}
+	b := DeviceMap{"phone": entry(1, 4), "laptop": entry(3, 2), "browser": entry(1, 7)}
+
+	ab := DeviceMap{}
+	ab.Merge(a)
+	ab.Merge(b)
+	ba := DeviceMap{}
+	ba.Merge(b)
+	ba.Merge(a)
+	for _, m := range []DeviceMap{ab, ba} {
+		if len(m) != 3 || m["phone"].Revision != 2 || m["laptop"].Revision != 3 || m["browser"].Revision != 1 {
+			t.Errorf("merge kept the wrong entries: %v", m)
+		}
+	}
+	ab.Merge(ab)
+	if len(ab) != 3 || !ab.covers(ba) || !ba.covers(ab) {
+		t.Error("merge is not idempotent")
+	}
+}
+
+func TestSyncDevicesConcurrently(t *testing.T) {
+	c := newTestClient(t, "")
+
+	const devices = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, devices)
+	for i := 0; i < devices; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			local := DeviceMap{fmt.Sprintf("device%d", i): entry(1, i)}
+			var err error
+			for attempt := 0; attempt < devices; attempt++ {
+				if _, err = c.SyncDevices("statistics", local); !errors.Is(err, ErrConflict) {
+					break
+				}
+			}
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	merged, err := c.SyncDevices("statistics", DeviceMap{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(merged) != devices {
+		t.Errorf("want %d devices, got %d", devices, len(merged))
+	}
+}
@@ -0,0 +1,177 @@
+package remote
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// maxValueSize limits what one PUT can store
+const maxValueSize = 1 << 20
+
+// Server is a reference key/value store for Client. Values are kept in memory,
+// and also in Dir (one file per key) if Dir is not empty, so they survive a restart.
+// It does no authentication; put it behind something that does if it faces the internet.
+type Server struct {
+	Dir    string
+	mu     sync.Mutex
+	values map[string][]byte
+}
+
+// NewServer creates a Server, keeping values in dir if it is not empty
+func NewServer(dir string) *Server {
+	return &Server{Dir: dir, values: make(map[string][]byte)}
+}
+
+// etag is derived from the value, so it doesn't need storing and survives restarts
+func etag(value []byte) string {
+	sum := sha256.Sum256(value)
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+func (s *Server) fileName(key string) string {
+	return filepath.Join(s.Dir, url.PathEscape(key))
+}
+
+// load returns the value of key, or nil; s.mu must be held
+func (s *Server) load(key string) ([]byte, error) {
+	if value, ok := s.values[key]; ok {
+		return value, nil
+	}
+	if s.Dir == "" {
+		return nil, nil
+	}
+	value, err := os.ReadFile(s.fileName(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.values[key] = value
+	return value, nil
+}
+
+// store sets (or, if value is nil, deletes) the value of key; s.mu must be held
+func (s *Server) store(key string, value []byte) error {
+	if s.Dir != "" {
+		var err error
+		if value == nil {
+			err = os.Remove(s.fileName(key))
+			if errors.Is(err, fs.ErrNotExist) {
+				err = nil
+			}
+		} else {
+			err = os.WriteFile(s.fileName(key), value, 0644)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if value == nil {
+		delete(s.values, key)
+	} else {
+		s.values[key] = value
+	}
+	return nil
+}
+
+// validKey allows letters, digits and . _ - /, but no empty or .. path segments
+func validKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, seg := range strings.Split(key, "/") {
+		if seg == "" || seg == "." || seg == ".." {
+			return false
+		}
+	}
+	for _, r := range key {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '.', r == '_', r == '-', r == '/':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// ServeHTTP implements http.Handler; the whole path, less the leading slash, is the key
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	if !validKey(key) {
+		http.Error(w, "bad key", http.StatusBadRequest)
+		return
+	}
+
+	// so the game can sync from a browser
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, PUT, DELETE")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, If-Match, If-None-Match")
+	w.Header().Set("Access-Control-Expose-Headers", "ETag")
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, err := s.load(key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if value == nil {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", etag(value))
+		w.Write(value)
+
+	case http.MethodPut:
+		if match := r.Header.Get("If-Match"); match != "" && (value == nil || match != etag(value)) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		if r.Header.Get("If-None-Match") == "*" && value != nil {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		newValue, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxValueSize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		if err := s.store(key, newValue); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("ETag", etag(newValue))
+		w.WriteHeader(http.StatusNoContent)
+
+	case http.MethodDelete:
+		if err := s.store(key, nil); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.Header().Set("Allow", "GET, PUT, DELETE, OPTIONS")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
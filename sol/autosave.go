@@ -0,0 +1,101 @@
+package sol
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"oddstream.games/gosol/util"
+)
+
+// The games are saved every ThePreferences.AutosaveMoves moves, when the window loses focus,
+// when a browser tab is hidden or an Android app is paused (see Suspend), as well as on exit,
+// so a crash, a closed browser tab or a killed app loses little.
+// Autosaves are encoded in the game loop, so they see a consistent game, but written in a goroutine.
+
+// savedGamesWriter makes sure that writes of saved games land in the order they were made,
+// so a slow background write can't overwrite a later one
+var savedGamesWriter struct {
+	sync.Mutex
+	made, written int // generation of the newest encoding, and of the newest one written
+}
+
+// writeSavedGamesInOrder writes bytes to st, unless something newer has already been written
+func writeSavedGamesInOrder(st Storage, generation int, bytes []byte) {
+	savedGamesWriter.Lock()
+	defer savedGamesWriter.Unlock()
+	if generation <= savedGamesWriter.written {
+		return
+	}
+	if err := writeSavedGames(st, bytes); err != nil {
+		log.Println("writeSavedGames", err)
+		return
+	}
+	savedGamesWriter.written = generation
+}
+
+// encodeSavedGames encodes the games now, returning the encoding and its generation
+func (b *Baize) encodeSavedGames() ([]byte, int, bool) {
+	bytes, err := marshalSavedGames(b.SavableGames())
+	if err != nil {
+		log.Println("marshalSavedGames", err)
+		return nil, 0, false
+	}
+	savedGamesWriter.Lock()
+	savedGamesWriter.made++
+	generation := savedGamesWriter.made
+	savedGamesWriter.Unlock()
+	b.movesSinceSave = 0
+	return bytes, generation, true
+}
+
+// Save the game for each variant, with its entire undo tree, and wait for it to be written
+func (b *Baize) Save() {
+	if DebugMode {
+		defer util.Duration(time.Now(), "Baize.Save")
+	}
+	if bytes, generation, ok := b.encodeSavedGames(); ok {
+		writeSavedGamesInOrder(TheStorage, generation, bytes)
+	}
+}
+
+// Autosave saves the games in the background
+func (b *Baize) Autosave() {
+	if NoGameSave {
+		return
+	}
+	if bytes, generation, ok := b.encodeSavedGames(); ok {
+		// TheStorage is passed now, as the player may switch profiles before the write happens
+		go writeSavedGamesInOrder(TheStorage, generation, bytes)
+	}
+}
+
+// countMoveForAutosave is called after each move, and autosaves every ThePreferences.AutosaveMoves moves
+func (b *Baize) countMoveForAutosave() {
+	b.movesSinceSave++
+	if ThePreferences.AutosaveMoves > 0 && b.movesSinceSave >= ThePreferences.AutosaveMoves {
+		b.Autosave()
+	}
+}
+
+// checkFocusForAutosave autosaves when the window loses focus; it needs the game to keep updating while unfocused
+func (b *Baize) checkFocusForAutosave() {
+	focused := ebiten.IsFocused()
+	if b.focused && !focused && b.movesSinceSave > 0 {
+		b.Autosave()
+	}
+	b.focused = focused
+}
+
+// Suspend saves the games now, and waits for them to be written. It is called from outside the game loop
+// when the loop is about to stop, when a browser tab is hidden or an Android app is paused,
+// as the game may be killed without warning then, and checkFocusForAutosave would never see the focus go.
+func Suspend() {
+	updating.Lock()
+	defer updating.Unlock()
+	if TheBaize == nil || NoGameSave || TheBaize.movesSinceSave == 0 {
+		return
+	}
+	TheBaize.Save()
+}
@@ -6,10 +6,10 @@ import (
 	"image"
 	"log"
 	"runtime"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"oddstream.games/gosol/input"
 	"oddstream.games/gosol/sound"
 	"oddstream.games/gosol/ui"
@@ -34,19 +34,36 @@ type Baize struct {
 	magic            uint32
 	script           ScriptInterface
 	piles            []*Pile
-	tail             []*Card // array of cards currently being dragged
-	bookmark         int     // index into undo stack
-	recycles         int     // number of available stock recycles
-	undoStack        []*SavableBaize
-	dirtyFlags       uint32 // what needs doing when we Update
-	moves            int    // number of possible (not useless) moves
-	fmoves           int    // number of possible moves to a Foundation (for enabling Collect button)
+	tail             []*Card                     // array of cards currently being dragged
+	bookmarks        []*Bookmark                 // named positions
+	recycles         int                         // number of available stock recycles (or redeals)
+	seed             int64                       // seed used to shuffle this deal, also used by Redeal
+	turn             int                         // player (1 or 2) to move in a two-player game, 0 otherwise
+	botTicks         int                         // ticks since the bot last moved
+	bot              bool                        // player 2 is a bot in this two-player game, decided when it was dealt
+	undoStack        []*SavableBaize             // path through undoNodes to the current position
+	undoNodes        []*UndoNode                 // every position reached in this deal
+	savedGames       map[string]*SavableUndoTree // games put aside, by variant
+	movesSinceSave   int                         // moves made since the games were last saved
+	focused          bool                        // window had the focus last Update, see checkFocusForAutosave
+	dirtyFlags       uint32                      // what needs doing when we Update
+	moves            int                         // number of possible (not useless) moves
+	fmoves           int                         // number of possible moves to a Foundation (for enabling Collect button)
 	stroke           *input.Stroke
 	dragStart        image.Point
 	dragOffset       image.Point
-	showMovableCards bool // show movable cards until the next move (default: false)
-	WindowWidth      int  // the most recent window width given to Layout
-	WindowHeight     int  // the most recent window height given to Layout
+	showMovableCards bool    // show movable cards until the next move (default: false)
+	pairCard         *Card   // first card of a pair selected by tapping (Pyramid et al)
+	peekCard         *Card   // face up card raised by a long press, so it can be seen in full
+	dropTargets      []*Pile // piles that would accept the tail being dragged
+	tapCycle         tapCycle
+	focus            *Pile // pile with the keyboard focus, nil if the keyboard isn't being used
+	focusIndex       int   // index of the focused card in the focus pile
+	gestures         input.GestureRecognizer
+	zoom             float64 // card size multiplier, changed by pinching
+	pinchStartZoom   float64 // zoom when the current pinch started
+	WindowWidth      int     // the most recent window width given to Layout
+	WindowHeight     int     // the most recent window height given to Layout
 }
 
 //--+----1----+----2----+----3----+----4----+----5----+----6----+----7----+----8
@@ -54,7 +71,7 @@ type Baize struct {
 // NewBaize is the factory func for the single Baize object
 func NewBaize() *Baize {
 	// let WindowWidth,WindowHeight be zero, so that the first Layout will trigger card scaling and pile placement
-	return &Baize{magic: BAIZEMAGIC, dragOffset: image.Point{0, 0}, dirtyFlags: 0xFFFF}
+	return &Baize{magic: BAIZEMAGIC, dragOffset: image.Point{0, 0}, dirtyFlags: 0xFFFF, zoom: 1.0}
 }
 
 func (b *Baize) flagSet(flag uint32) bool {
@@ -125,6 +142,7 @@ func (b *Baize) NewDeal() {
 	Shuffle(stockPile)
 
 	b.showMovableCards = false
+	b.StartTurns()
 	b.script.StartGame()
 	b.UndoPush()
 	b.FindDestinations()
@@ -145,6 +163,15 @@ func (b *Baize) ShowVariantPicker(group string) {
 	TheUI.ShowVariantPicker(VariantNames(group))
 }
 
+// FindVariant lists the variants whose names contain query
+func (b *Baize) FindVariant(query string) {
+	if vnames := FindVariantNames(query); len(vnames) > 0 {
+		TheUI.ShowVariantPicker(vnames)
+	} else {
+		TheUI.Toast(fmt.Sprintf("No variant called '%s'", query))
+	}
+}
+
 func (b *Baize) MirrorSlots() {
 	/*
 		0 1 2 3 4 5
@@ -172,6 +199,11 @@ func (b *Baize) MirrorSlots() {
 			continue // ignore hidden pile
 		}
 		p.SetSlot(image.Point{X: maxX - slot.X + minX, Y: slot.Y})
+		if shift := p.SlotShift(); shift.X != 0 {
+			// a pile shifted right by a fraction of a slot becomes a pile shifted left
+			p.SetSlot(image.Point{X: maxX - slot.X + minX - 1, Y: slot.Y})
+			p.SetSlotShift(image.Point{X: 100 - shift.X, Y: shift.Y})
+		}
 		switch p.FanType() {
 		case FAN_RIGHT:
 			p.SetFanType(FAN_LEFT)
@@ -187,8 +219,13 @@ func (b *Baize) MirrorSlots() {
 
 func (b *Baize) Reset() {
 	b.tail = nil
+	b.pairCard = nil
+	b.peekCard = nil
+	b.tapCycle = tapCycle{}
+	b.focus = nil
 	b.undoStack = nil
-	b.bookmark = 0
+	b.undoNodes = nil
+	b.bookmarks = nil
 	MarkAllCardsImmovable()
 }
 
@@ -227,6 +264,7 @@ func (b *Baize) StartFreshGame() {
 		b.MirrorSlots()
 	}
 	// b.FindBuddyPiles()
+	b.FindCoveringPiles()
 
 	TheUI.SetTitle(b.LongVariantName())
 
@@ -235,6 +273,7 @@ func (b *Baize) StartFreshGame() {
 	b.dirtyFlags = 0xFFFF
 
 	b.showMovableCards = false
+	b.StartTurns()
 	b.script.StartGame()
 	b.UndoPush()
 	b.FindDestinations()
@@ -243,38 +282,19 @@ func (b *Baize) StartFreshGame() {
 	TheStatistics.WelcomeToast(b.LongVariantName())
 }
 
+// ChangeVariant puts the current game aside, and resumes the new variant's game, or starts one
 func (b *Baize) ChangeVariant(newVariant string) {
-	// a virgin game has one state on the undo stack
-	if len(b.undoStack) > 1 && !b.Complete() {
-		TheStatistics.RecordLostGame(b.LongVariantName())
-	}
+	b.putGameAside()
 	ThePreferences.Variant = newVariant
 	b.StartFreshGame()
-}
-
-func (b *Baize) SetUndoStack(undoStack []*SavableBaize) {
-	b.undoStack = undoStack
-	sav := b.UndoPeek()
-	b.UpdateFromSavable(sav)
-	b.FindDestinations()
-	if b.Complete() {
-		TheUI.Toast("Complete")
-		TheUI.ShowFAB("star", ebiten.KeyN)
-		b.StartSpinning()
-	} else if b.Conformant() {
-		TheUI.ShowFAB("done_all", ebiten.KeyC)
-	} else if b.moves == 0 {
-		TheUI.Toast("No movable cards")
-		TheUI.ShowFAB("star", ebiten.KeyN)
-	} else {
-		TheUI.HideFAB()
-	}
-	b.UpdateStatusbar()
+	b.resumeSavedGame()
 }
 
 // findPileAt finds the Pile under the mouse click or touch
+// piles are searched in reverse order, because piles drawn later may overlap earlier ones
 func (b *Baize) FindPileAt(pt image.Point) *Pile {
-	for _, p := range b.piles {
+	for i := len(b.piles) - 1; i >= 0; i-- {
+		p := b.piles[i]
 		if pt.In(p.ScreenRect()) {
 			return p
 		}
@@ -284,7 +304,8 @@ func (b *Baize) FindPileAt(pt image.Point) *Pile {
 
 // FindCardAt finds the Card under the mouse click or touch
 func (b *Baize) FindCardAt(pt image.Point) *Card {
-	for _, p := range b.piles {
+	for j := len(b.piles) - 1; j >= 0; j-- {
+		p := b.piles[j]
 		for i := p.Len() - 1; i >= 0; i-- {
 			c := p.Get(i)
 			if pt.In(c.ScreenRect()) {
@@ -300,7 +321,7 @@ func (b *Baize) LargestIntersection(c *Card) *Pile {
 	var pile *Pile = nil
 	cardRect := c.BaizeRect()
 	for _, p := range b.piles {
-		if p == c.Owner() {
+		if p == c.Owner() || p.Blocked() {
 			continue
 		}
 		pileRect := p.FannedBaizeRect()
@@ -363,20 +384,22 @@ func (b *Baize) MakeTail(c *Card) bool {
 
 func (b *Baize) AfterUserMove() {
 	b.showMovableCards = false
+	b.pairCard = nil
 	b.script.AfterMove()
 	b.UndoPush()
 	b.FindDestinations()
 	b.UpdateStatusbar()
+	b.countMoveForAutosave()
 
 	if b.Complete() {
-		TheUI.ShowFAB("star", ebiten.KeyN)
+		TheUI.ShowFAB("star", "NewDeal")
 		b.StartSpinning()
 		TheStatistics.RecordWonGame(b.LongVariantName())
 	} else if b.Conformant() {
-		TheUI.ShowFAB("done_all", ebiten.KeyC)
+		TheUI.ShowFAB("done_all", "Collect")
 	} else if b.moves == 0 {
 		TheUI.Toast("No movable cards")
-		TheUI.ShowFAB("star", ebiten.KeyN)
+		TheUI.ShowFAB("star", "NewDeal")
 	} else {
 		TheUI.HideFAB()
 	}
@@ -391,6 +414,7 @@ If the Input starts on a Card, then a tail of cards is formed.
 */
 func (b *Baize) InputStart(v input.StrokeEvent) {
 	b.stroke = v.Stroke
+	b.ClearFocus()
 
 	if con := TheUI.FindContainerAt(v.X, v.Y); con != nil {
 		if con.StartDrag(b.stroke) {
@@ -400,11 +424,12 @@ func (b *Baize) InputStart(v input.StrokeEvent) {
 		}
 	} else {
 		pt := image.Pt(v.X, v.Y)
-		if c := b.FindCardAt(pt); c != nil {
+		// while the bot is moving, cards and piles can't be touched, but the baize can still be dragged
+		if c := b.FindCardAt(pt); c != nil && !b.BotsTurn() {
 			b.StartTailDrag(c)
 			b.stroke.SetDraggedObject(c)
 		} else {
-			if p := b.FindPileAt(pt); p != nil {
+			if p := b.FindPileAt(pt); p != nil && !b.BotsTurn() {
 				b.stroke.SetDraggedObject(p)
 			} else {
 				if b.StartDrag() {
@@ -429,6 +454,9 @@ func (b *Baize) InputMove(v input.StrokeEvent) {
 		con := v.Stroke.DraggedObject().(ui.Container)
 		con.DragBy(v.Stroke.PositionDiff())
 	case *Card:
+		if b.peekCard != nil {
+			break // peeking, not dragging
+		}
 		b.DragTailBy(v.Stroke.PositionDiff())
 		if c, ok := v.Stroke.DraggedObject().(*Card); ok {
 			if p := b.LargestIntersection(c); p != nil {
@@ -457,48 +485,22 @@ func (b *Baize) InputStop(v input.StrokeEvent) {
 		con.StopDrag()
 	case *Card:
 		c := v.Stroke.DraggedObject().(*Card)
-		if c.WasDragged() {
-			src := c.Owner()
+		if b.peekCard != nil {
+			b.peekCard = nil
+		} else if c.WasDragged() {
 			// tap handled elsewhere
 			// tap is time-limited
-			if dst := b.LargestIntersection(c); dst == nil {
+			dst := b.LargestIntersection(c)
+			if ThePreferences.SnapDrops && (dst == nil || !b.isDropTarget(dst)) {
+				if snap := b.SnapDropTarget(c); snap != nil {
+					dst = snap
+				}
+			}
+			if dst == nil {
 				// println("no intersection for", c.String())
 				b.CancelTailDrag()
 			} else {
-				var ok bool
-				var err error
-				// generically speaking, can this tail be moved?
-				if ok, err = src.CanMoveTail(b.tail); !ok {
-					sound.Play("Blip")
-					TheUI.Toast(err.Error())
-					b.CancelTailDrag()
-				} else {
-					if ok, err = dst.vtable.CanAcceptTail(b.tail); !ok {
-						sound.Play("Blip")
-						TheUI.Toast(err.Error())
-						b.CancelTailDrag()
-					} else {
-						// it's ok to move this tail
-						if src == dst {
-							b.CancelTailDrag()
-						} else if ok, err = b.script.TailMoveError(b.tail); !ok {
-							sound.Play("Blip")
-							TheUI.Toast(err.Error())
-							b.CancelTailDrag()
-						} else {
-							crc := b.CRC()
-							if len(b.tail) == 1 {
-								MoveCard(src, dst)
-							} else {
-								MoveTail(c, dst)
-							}
-							if crc != b.CRC() {
-								b.AfterUserMove()
-							}
-							b.StopTailDrag()
-						}
-					}
-				}
+				b.DropTail(c, dst)
 			}
 		}
 	case *Pile:
@@ -511,10 +513,63 @@ func (b *Baize) InputStop(v input.StrokeEvent) {
 	}
 }
 
+// DropTail moves the tail being dragged (which starts with card c) to dst, if the rules allow it,
+// otherwise it explains why not and puts the tail back where it came from
+func (b *Baize) DropTail(c *Card, dst *Pile) {
+	src := c.Owner()
+	var ok bool
+	var err error
+	// generically speaking, can this tail be moved?
+	if ok, err = src.CanMoveTail(b.tail); !ok {
+		sound.Play("Blip")
+		TheUI.Toast(err.Error())
+		b.CancelTailDrag()
+	} else if ps, isPairs := b.script.(PairScriptInterface); isPairs && !src.IsStock() {
+		// cards are not moved to a pile, but removed in pairs
+		b.DropPair(ps, c, dst)
+	} else {
+		if ok, err = dst.vtable.CanAcceptTail(b.tail); !ok {
+			sound.Play("Blip")
+			TheUI.Toast(err.Error())
+			b.CancelTailDrag()
+		} else {
+			// it's ok to move this tail
+			if src == dst {
+				b.CancelTailDrag()
+			} else if ok, err = b.script.TailMoveError(b.tail); !ok {
+				sound.Play("Blip")
+				TheUI.Toast(err.Error())
+				b.CancelTailDrag()
+			} else {
+				crc := b.CRC()
+				if len(b.tail) == 1 {
+					MoveCard(src, dst)
+				} else {
+					MoveTail(c, dst)
+				}
+				if crc != b.CRC() {
+					b.AfterUserMove()
+				}
+				b.StopTailDrag()
+			}
+		}
+	}
+}
+
+// InputLongPress raises a face up card, so it can be seen in full until the stroke is released;
+// the tail that was started with the stroke is put back, so the card doesn't get dragged
+func (b *Baize) InputLongPress(v input.StrokeEvent) {
+	if c, ok := v.Stroke.DraggedObject().(*Card); ok && !c.Prone() {
+		b.CancelTailDrag()
+		b.peekCard = c
+	}
+}
+
 func (b *Baize) InputCancel(v input.StrokeEvent) {
 	if v.Stroke.DraggedObject() == nil {
 		log.Panic("*** cancel stroke with nil dragged object ***")
 	}
+	b.peekCard = nil
 	switch v.Stroke.DraggedObject().(type) { // type switch
 	case ui.Container:
 		con := v.Stroke.DraggedObject().(ui.Container)
@@ -542,6 +597,10 @@ func (b *Baize) InputTap(v input.StrokeEvent) {
 		// if the script doesn't want to do anything, it can call pile.vtable.TailTapped
 		// which will either ignore it (eg Foundation, Discard)
 		// or use Pile.DefaultTailTapped
+		if b.peekCard != nil || len(b.tail) == 0 {
+			// peeking, or the tail has already been put back
+			break
+		}
 		crc := b.CRC()
 		b.script.TailTapped(b.tail)
 		if crc != b.CRC() {
@@ -578,6 +637,8 @@ func (b *Baize) NotifyCallback(v input.StrokeEvent) {
 		b.InputCancel(v)
 	case input.Tap:
 		b.InputTap(v)
+	case input.LongPress:
+		b.InputLongPress(v)
 	default:
 		log.Panic("*** unknown stroke event ***", v.Event)
 	}
@@ -604,6 +665,7 @@ func (b *Baize) DragTailBy(dx, dy int) {
 func (b *Baize) StartTailDrag(c *Card) {
 	if b.MakeTail(c) {
 		b.ApplyToTail((*Card).StartDrag)
+		b.FindDropTargets()
 		ebiten.SetCursorMode(ebiten.CursorModeHidden)
 	} else {
 		println("failed to make a tail")
@@ -614,15 +676,20 @@ func (b *Baize) StopTailDrag() {
 	ebiten.SetCursorMode(ebiten.CursorModeVisible)
 	b.ApplyToTail((*Card).StopDrag)
 	b.tail = nil
+	b.dropTargets = nil
 }
 
 func (b *Baize) CancelTailDrag() {
 	ebiten.SetCursorMode(ebiten.CursorModeVisible)
 	b.ApplyToTail((*Card).CancelDrag)
 	b.tail = nil
+	b.dropTargets = nil
 }
 
 func (b *Baize) Collect() {
+	if b.BotsTurn() {
+		return
+	}
 	outerCRC := b.CRC()
 	for {
 		innerCRC := b.CRC()
@@ -680,14 +747,14 @@ func (b *Baize) ScaleCards() bool {
 	// Card padding is 10% of card height/width
 
 	if ThePreferences.FixedCards {
-		CardWidth = ThePreferences.FixedCardWidth
+		CardWidth = int(float64(ThePreferences.FixedCardWidth) * b.zoom)
 		PilePaddingX = CardWidth / 10
-		CardHeight = ThePreferences.FixedCardHeight
+		CardHeight = int(float64(ThePreferences.FixedCardHeight) * b.zoom)
 		PilePaddingY = CardHeight / 10
 		cardsWidth := PilePaddingX + CardWidth*(maxX+2)
 		LeftMargin = (b.WindowWidth - cardsWidth) / 2
 	} else {
-		slotWidth := float64(b.WindowWidth) / float64(maxX+2)
+		slotWidth := float64(b.WindowWidth) / float64(maxX+2) * b.zoom
 		PilePaddingX = int(slotWidth / 10)
 		CardWidth = int(slotWidth) - PilePaddingX
 		slotHeight := slotWidth * ThePreferences.CardRatio
@@ -709,6 +776,9 @@ func (b *Baize) ScaleCards() bool {
 }
 
 func (b *Baize) PercentComplete() int {
+	if ps, ok := b.script.(PositionalScriptInterface); ok {
+		return ps.PercentComplete()
+	}
 	var pairs, unsorted, percent int
 	for _, p := range b.piles {
 		if p.Len() > 1 {
@@ -745,11 +815,23 @@ func (b *Baize) UpdateStatusbar() {
 	} else {
 		TheUI.SetWaste(-1) // previous variant may have had a waste, and this one does not
 	}
-	TheUI.SetMiddle(fmt.Sprintf("MOVES: %d,%d", b.moves, b.fmoves))
+	if b.turn != 0 {
+		TheUI.SetMiddle(fmt.Sprintf("%s TO MOVE", strings.ToUpper(b.PlayerName(b.turn))))
+	} else {
+		TheUI.SetMiddle(fmt.Sprintf("MOVES: %d,%d", b.moves, b.fmoves))
+	}
 	TheUI.SetPercent(b.PercentComplete())
 }
 
 func (b *Baize) Conformant() bool {
+	if _, ok := b.script.(PositionalScriptInterface); ok {
+		// there is nothing to collect
+		return false
+	}
+	if b.TwoPlayer() {
+		// collecting would move both players' cards
+		return false
+	}
 	for _, p := range b.piles {
 		if !p.vtable.Conformant() {
 			return false
@@ -759,6 +841,12 @@ func (b *Baize) Conformant() bool {
 }
 
 func (b *Baize) Complete() bool {
+	if ps, ok := b.script.(PositionalScriptInterface); ok {
+		return ps.Complete()
+	}
+	if tp, ok := b.script.(TwoPlayerScriptInterface); ok {
+		return tp.Winner() != 0
+	}
 	for _, p := range b.piles {
 		if !p.vtable.Complete() {
 			return false
@@ -803,8 +891,8 @@ func (b *Baize) Layout(outsideWidth, outsideHeight int) (int, int) {
 		if b.flagSet(dirtyPilePositions) {
 			for _, p := range b.piles {
 				p.SetBaizePos(image.Point{
-					X: LeftMargin + (p.Slot().X * (CardWidth + PilePaddingX)),
-					Y: TopMargin + (p.Slot().Y * (CardHeight + PilePaddingY)),
+					X: LeftMargin + (p.Slot().X * (CardWidth + PilePaddingX)) + (p.SlotShift().X * (CardWidth + PilePaddingX) / 100),
+					Y: TopMargin + (p.Slot().Y * (CardHeight + PilePaddingY)) + (p.SlotShift().Y * (CardHeight + PilePaddingY) / 100),
 				})
 			}
 			b.clearFlag(dirtyPilePositions)
@@ -834,6 +922,12 @@ func (b *Baize) Layout(outsideWidth, outsideHeight int) (int, int) {
 // Update the baize state (transitions, user input)
 func (b *Baize) Update() error {
 
+	b.UpdateGamepads()
+	b.gestures.Update(b)
+	b.checkFocusForAutosave()
+	ImportPendingBundle()
+	MergePendingPull()
+
 	if b.stroke == nil {
 		input.StartStroke(b) // this will set b.stroke when "start" received
 	} else {
@@ -847,12 +941,16 @@ func (b *Baize) Update() error {
 		p.Update()
 	}
 
-	for k := ebiten.Key(0); k <= ebiten.KeyMax; k++ {
-		if inpututil.IsKeyJustReleased(k) {
-			Execute(k)
+	if b.stroke == nil && b.BotsTurn() {
+		b.botTicks++
+		if b.botTicks > botDelay {
+			b.botTicks = 0
+			b.BotMove()
 		}
 	}
 
+	b.UpdateKeys()
+
 	TheUI.Update()
 
 	return nil
@@ -872,6 +970,7 @@ func (b *Baize) Draw(screen *ebiten.Image) {
 	for _, p := range b.piles {
 		p.DrawStaticCards(screen)
 	}
+	b.drawDropTargets(screen)
 	for _, p := range b.piles {
 		p.DrawTransitioningCards(screen)
 	}
@@ -881,13 +980,22 @@ func (b *Baize) Draw(screen *ebiten.Image) {
 	for _, p := range b.piles {
 		p.DrawDraggingCards(screen)
 	}
+	if b.peekCard != nil {
+		b.peekCard.Draw(screen)
+	}
+	if b.focus != nil {
+		b.drawFocus(screen)
+	}
 
 	TheUI.Draw(screen)
+	if input.TheGamepadCursor.Visible() {
+		drawGamepadCursor(screen)
+	}
 	// if DebugMode {
 	// var ms runtime.MemStats
 	// runtime.ReadMemStats(&ms)
 	// ebitenutil.DebugPrint(screen, fmt.Sprintf("FPS %v, Alloc %v, NumGC %v", ebiten.CurrentTPS(), ms.Alloc, ms.NumGC))
-	// ebitenutil.DebugPrint(screen, fmt.Sprintf("%v %v", b.bookmark, len(b.undoStack)))
+	// ebitenutil.DebugPrint(screen, fmt.Sprintf("%v %v", len(b.bookmarks), len(b.undoStack)))
 	// bounds := screen.Bounds()
 	// ebitenutil.DebugPrint(screen, bounds.String())
 	// }
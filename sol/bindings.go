@@ -0,0 +1,252 @@
+package sol
+
+//lint:file-ignore ST1005 Error messages are toasted, so need to be capitalized
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"oddstream.games/gosol/sound"
+	"oddstream.games/gosol/ui"
+)
+
+// KeyBinding is a key, and the modifier keys that must be held down with it
+type KeyBinding struct {
+	Key   ebiten.Key
+	Ctrl  bool
+	Alt   bool
+	Shift bool
+}
+
+// CommandNames lists the commands that can be bound to keys, in the order they are shown in the key bindings drawer
+var CommandNames = []string{
+	"NewDeal", "RestartDeal", "Undo", "Bookmark", "GotoBookmark", "Bookmarks", "Branches", "Collect", "Hint",
+	"FindGame", "Wikipedia", "Statistics", "Settings", "KeyBindings", "Profiles", "Menu", "Cancel",
+	"Spin", "StopSpin", "HideFAB", "Refan", "Exit",
+}
+
+// keyBindingTable maps key presses to commands, built from Preferences.KeyBindings by LoadKeyBindings
+var keyBindingTable = map[KeyBinding]ui.Command{}
+
+// rebinding is the command waiting for a new key to be pressed, or empty
+var rebinding string
+
+// DefaultKeyBindings is the key binding for each command, used until the player changes them.
+// A command may have more than one binding, separated by commas.
+func DefaultKeyBindings() map[string]string {
+	return map[string]string{
+		"NewDeal":      "N",
+		"RestartDeal":  "R",
+		"Undo":         "U, Ctrl+Z",
+		"Bookmark":     "S",
+		"GotoBookmark": "L",
+		"Bookmarks":    "K",
+		"Branches":     "B",
+		"Collect":      "C",
+		"Hint":         "H",
+		"FindGame":     "F",
+		"Wikipedia":    "F1",
+		"Statistics":   "F2",
+		"Settings":     "F3",
+		"KeyBindings":  "F4",
+		"Profiles":     "P",
+		"Menu":         "ContextMenu",
+		"Cancel":       "Escape",
+		"Spin":         "F5",
+		"StopSpin":     "F6",
+		"HideFAB":      "F8",
+		"Refan":        "Tab",
+		"Exit":         "X",
+	}
+}
+
+// String returns the binding in the form used in preferences, eg "Ctrl+Z"
+func (kb KeyBinding) String() string {
+	var sb strings.Builder
+	if kb.Ctrl {
+		sb.WriteString("Ctrl+")
+	}
+	if kb.Alt {
+		sb.WriteString("Alt+")
+	}
+	if kb.Shift {
+		sb.WriteString("Shift+")
+	}
+	sb.WriteString(kb.Key.String())
+	return sb.String()
+}
+
+// ParseKeyBinding turns a string like "Ctrl+Shift+Z" into a KeyBinding
+func ParseKeyBinding(s string) (KeyBinding, error) {
+	var kb KeyBinding
+	parts := strings.Split(s, "+")
+	for _, mod := range parts[:len(parts)-1] {
+		switch strings.ToLower(strings.TrimSpace(mod)) {
+		case "ctrl", "control":
+			kb.Ctrl = true
+		case "alt":
+			kb.Alt = true
+		case "shift":
+			kb.Shift = true
+		default:
+			return kb, fmt.Errorf("Unknown modifier key '%s' in '%s'", mod, s)
+		}
+	}
+	name := strings.TrimSpace(parts[len(parts)-1])
+	if err := kb.Key.UnmarshalText([]byte(name)); err != nil || isModifierKey(kb.Key) {
+		return kb, fmt.Errorf("Unknown key '%s' in '%s'", name, s)
+	}
+	return kb, nil
+}
+
+// withDefaultKeyBindings adds the default bindings for any commands missing from bindings;
+// a command the player has unbound is there, with an empty binding
+func withDefaultKeyBindings(bindings map[string]string) map[string]string {
+	if bindings == nil {
+		return DefaultKeyBindings()
+	}
+	for command, kbs := range DefaultKeyBindings() {
+		if _, ok := bindings[command]; !ok {
+			bindings[command] = kbs
+		}
+	}
+	return bindings
+}
+
+// parseKeyBindings parses a comma separated list of bindings; an empty string means unbound
+func parseKeyBindings(s string) ([]KeyBinding, error) {
+	var kbs []KeyBinding
+	for _, str := range strings.Split(s, ",") {
+		if strings.TrimSpace(str) == "" {
+			continue
+		}
+		kb, err := ParseKeyBinding(str)
+		if err != nil {
+			return nil, err
+		}
+		kbs = append(kbs, kb)
+	}
+	return kbs, nil
+}
+
+func isModifierKey(k ebiten.Key) bool {
+	switch k {
+	case ebiten.KeyAlt, ebiten.KeyAltLeft, ebiten.KeyAltRight,
+		ebiten.KeyControl, ebiten.KeyControlLeft, ebiten.KeyControlRight,
+		ebiten.KeyShift, ebiten.KeyShiftLeft, ebiten.KeyShiftRight,
+		ebiten.KeyMeta, ebiten.KeyMetaLeft, ebiten.KeyMetaRight:
+		return true
+	}
+	return false
+}
+
+// keyBindingConflict returns a description of what kb is already used for, other than command, or an empty string
+func keyBindingConflict(kb KeyBinding, command string) string {
+	if _, ok := NavigationTable[kb.Key]; ok && !kb.Ctrl && !kb.Alt && !kb.Shift {
+		return "keyboard play"
+	}
+	if cmd, ok := keyBindingTable[kb]; ok && string(cmd) != command {
+		return string(cmd)
+	}
+	return ""
+}
+
+// LoadKeyBindings builds the key binding table from preferences.
+// Bindings that can't be parsed, or that clash with an earlier binding, are ignored and reported in the returned error.
+func LoadKeyBindings() error {
+	var problems []string
+	keyBindingTable = map[KeyBinding]ui.Command{}
+	for _, command := range CommandNames {
+		kbs, err := parseKeyBindings(ThePreferences.KeyBindings[command])
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		for _, kb := range kbs {
+			if other := keyBindingConflict(kb, command); other != "" {
+				problems = append(problems, fmt.Sprintf("%s is bound to both %s and %s", kb, other, command))
+				continue
+			}
+			keyBindingTable[kb] = ui.Command(command)
+		}
+	}
+	if problems != nil {
+		sort.Strings(problems)
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// UpdateKeys executes the command bound to any key just pressed
+func (b *Baize) UpdateKeys() {
+	if TheUI.Typing() {
+		return // the keys are going into a text input
+	}
+	for k := ebiten.Key(0); k <= ebiten.KeyMax; k++ {
+		if isModifierKey(k) || !inpututil.IsKeyJustPressed(k) {
+			continue
+		}
+		kb := KeyBinding{
+			Key:   k,
+			Ctrl:  ebiten.IsKeyPressed(ebiten.KeyControl),
+			Alt:   ebiten.IsKeyPressed(ebiten.KeyAlt),
+			Shift: ebiten.IsKeyPressed(ebiten.KeyShift),
+		}
+		if rebinding != "" {
+			FinishRebinding(kb)
+			continue
+		}
+		if fn, ok := NavigationTable[k]; ok && kb == (KeyBinding{Key: k}) && TheUI.VisibleDrawer() == nil {
+			fn()
+		} else if cmd, ok := keyBindingTable[kb]; ok {
+			Execute(cmd)
+		}
+	}
+}
+
+// ShowKeyBindingsDrawer lists the commands and their key bindings
+func ShowKeyBindingsDrawer() {
+	var bindings = map[string]string{}
+	for _, command := range CommandNames {
+		bindings[command] = ThePreferences.KeyBindings[command]
+	}
+	if rebinding != "" {
+		bindings[rebinding] = "press a key..."
+	}
+	TheUI.ShowKeyBindingsDrawer(CommandNames, bindings)
+}
+
+// StartRebinding waits for the next key press, which will become the binding for command
+func StartRebinding(command string) {
+	rebinding = command
+	TheUI.Toast(fmt.Sprintf("Press the new key for %s (Escape to cancel, Delete to clear)", command))
+	ShowKeyBindingsDrawer()
+}
+
+// FinishRebinding binds kb to the command waiting for a new key, unless kb is already being used
+func FinishRebinding(kb KeyBinding) {
+	command := rebinding
+	rebinding = ""
+	switch {
+	case kb == KeyBinding{Key: ebiten.KeyEscape}:
+		// leave the binding as it was
+	case kb == KeyBinding{Key: ebiten.KeyDelete}, kb == KeyBinding{Key: ebiten.KeyBackspace}:
+		ThePreferences.KeyBindings[command] = ""
+	default:
+		if other := keyBindingConflict(kb, command); other != "" {
+			sound.Play("Blip")
+			TheUI.Toast(fmt.Sprintf("%s is already used for %s", kb, other))
+		} else {
+			ThePreferences.KeyBindings[command] = kb.String()
+		}
+	}
+	if err := LoadKeyBindings(); err != nil {
+		TheUI.Toast(err.Error())
+	}
+	ThePreferences.Save()
+	ShowKeyBindingsDrawer()
+}
@@ -0,0 +1,66 @@
+package sol
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseKeyBinding(t *testing.T) {
+	for _, tc := range []struct {
+		in, out string // out is "" when in should not parse
+	}{
+		{"Z", "Z"},
+		{"ctrl+z", "Ctrl+Z"},
+		{"Control + Shift + F1", "Ctrl+Shift+F1"},
+		{"Shift+Alt+Ctrl+A", "Ctrl+Alt+Shift+A"},
+		{"Hyper+Z", ""},
+		{"Ctrl+", ""},
+		{"Shift", ""},
+		{"Ctrl+Banana", ""},
+	} {
+		kb, err := ParseKeyBinding(tc.in)
+		if tc.out == "" {
+			if err == nil {
+				t.Errorf("%q parsed as %s", tc.in, kb)
+			}
+			continue
+		}
+		if err != nil || kb.String() != tc.out {
+			t.Errorf("%q parsed as %s, %v; expected %s", tc.in, kb, err, tc.out)
+			continue
+		}
+		if again, err := ParseKeyBinding(kb.String()); err != nil || again != kb {
+			t.Errorf("%s did not survive being formatted and parsed again", kb)
+		}
+	}
+	if kbs, err := parseKeyBindings("U, Ctrl+Z, "); err != nil || len(kbs) != 2 {
+		t.Errorf("list parsed as %v, %v", kbs, err)
+	}
+}
+
+func TestLoadKeyBindings(t *testing.T) {
+	saved := ThePreferences.KeyBindings
+	defer func() { ThePreferences.KeyBindings = saved }()
+
+	for _, tc := range []struct {
+		command, bindings string
+		problem           string // "" when there should be none
+	}{
+		{"Hint", "H", ""},
+		{"Hint", "", ""},
+		{"Hint", "N", "N is bound to both"},
+		{"Hint", "ArrowLeft", "keyboard play"},
+		{"Hint", "Ctrl+ArrowLeft", ""},
+		{"Hint", "Hyper+H", "Unknown modifier"},
+	} {
+		ThePreferences.KeyBindings = DefaultKeyBindings()
+		ThePreferences.KeyBindings[tc.command] = tc.bindings
+		err := LoadKeyBindings()
+		switch {
+		case tc.problem == "" && err != nil:
+			t.Errorf("%s = %q: %v", tc.command, tc.bindings, err)
+		case tc.problem != "" && (err == nil || !strings.Contains(err.Error(), tc.problem)):
+			t.Errorf("%s = %q gave %v, expected %q", tc.command, tc.bindings, err, tc.problem)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package sol
+
+//lint:file-ignore ST1005 Error messages are toasted, so need to be capitalized
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"oddstream.games/gosol/sound"
+	"oddstream.games/gosol/ui"
+)
+
+// Bookmark is a named position. It keeps its own copy of the position, so it outlives
+// the undo tree node it was made from, and can be restored even if that node has gone.
+type Bookmark struct {
+	Name  string
+	Move  int // number of moves from the deal when the bookmark was made
+	State *SavableBaize
+}
+
+// bookmarkNames are given to new bookmarks in turn, until the player names them; the first one not in use is chosen
+const bookmarkNames = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// maxBookmarkName is how long a bookmark's name can be
+const maxBookmarkName = 16
+
+// renamingBookmark is the bookmark whose new name is being typed, or -1
+var renamingBookmark = -1
+
+// nextBookmarkName returns the first unused letter, or if they are all in use, the first unused number
+func (b *Baize) nextBookmarkName() string {
+	inUse := make(map[string]bool)
+	for _, bm := range b.bookmarks {
+		inUse[bm.Name] = true
+	}
+	for _, r := range bookmarkNames {
+		if !inUse[string(r)] {
+			return string(r)
+		}
+	}
+	for n := len(bookmarkNames) + 1; ; n++ {
+		if name := strconv.Itoa(n); !inUse[name] {
+			return name
+		}
+	}
+}
+
+// checkBookmarkName returns an error if name can't be given to bookmark i
+func (b *Baize) checkBookmarkName(name string, i int) error {
+	if name == "" {
+		return errors.New("A bookmark needs a name")
+	}
+	if len([]rune(name)) > maxBookmarkName {
+		return fmt.Errorf("Bookmark names can be up to %d letters long", maxBookmarkName)
+	}
+	for j, bm := range b.bookmarks {
+		if j != i && bm.Name == name {
+			return fmt.Errorf("There is already a bookmark called %s", name)
+		}
+	}
+	return nil
+}
+
+// SavePosition adds a bookmark for the current position
+func (b *Baize) SavePosition() {
+	if b.Complete() {
+		TheUI.Toast("Cannot bookmark a completed game") // otherwise the stats can be cooked
+		sound.Play("Blip")
+		return
+	}
+	sb := b.UndoPeek()
+	for _, bm := range b.bookmarks {
+		if bm.State.SamePosition(sb) {
+			TheUI.Toast(fmt.Sprintf("Position already bookmarked as %s", bm.Name))
+			sound.Play("Blip")
+			return
+		}
+	}
+	name := b.nextBookmarkName()
+	b.bookmarks = append(b.bookmarks, &Bookmark{Name: name, Move: len(b.undoStack) - 1, State: sb})
+	TheUI.Toast(fmt.Sprintf("Position bookmarked as %s", name))
+}
+
+// NewBookmark bookmarks the current position from the bookmarks drawer, and shows the bookmark ready to be named
+func (b *Baize) NewBookmark() {
+	n := len(b.bookmarks)
+	b.SavePosition()
+	if len(b.bookmarks) > n {
+		b.StartRenamingBookmark(n)
+	} else {
+		b.ShowBookmarksDrawer()
+	}
+}
+
+// LoadPosition goes to the most recent bookmark
+func (b *Baize) LoadPosition() {
+	if len(b.bookmarks) == 0 {
+		TheUI.Toast("No bookmark")
+		sound.Play("Blip")
+		return
+	}
+	b.GotoBookmark(len(b.bookmarks) - 1)
+}
+
+// GotoBookmark makes bookmark i the current position
+func (b *Baize) GotoBookmark(i int) {
+	if i < 0 || i >= len(b.bookmarks) {
+		return
+	}
+	if b.Complete() {
+		TheUI.Toast("Cannot change a completed game") // otherwise the stats can be cooked
+		sound.Play("Blip")
+		return
+	}
+	bm := b.bookmarks[i]
+	for n, node := range b.undoNodes {
+		if node.State == bm.State || node.State.SamePosition(bm.State) {
+			b.GotoUndoNode(n)
+			return
+		}
+	}
+	// the position is no longer in the undo tree, so it becomes a move from the current position
+	b.UpdateFromSavable(bm.State)
+	b.UndoPush()
+	bm.State = b.UndoPeek()
+	b.FindDestinations()
+	b.UpdateStatusbar()
+}
+
+// TapBookmark goes to bookmark i, or if it is the current position, shows it ready to be renamed
+func (b *Baize) TapBookmark(i int) {
+	if i >= 0 && i < len(b.bookmarks) && b.bookmarks[i].State.SamePosition(b.UndoPeek()) {
+		b.StartRenamingBookmark(i)
+		return
+	}
+	b.GotoBookmark(i)
+}
+
+// StartRenamingBookmark shows bookmark i as a text input, to type its new name into
+func (b *Baize) StartRenamingBookmark(i int) {
+	if i < 0 || i >= len(b.bookmarks) {
+		return
+	}
+	renamingBookmark = i
+	b.showBookmarksDrawer(i)
+}
+
+// FinishRenamingBookmark gives the bookmark being renamed its new name
+func (b *Baize) FinishRenamingBookmark(name string) {
+	b.RenameBookmark(renamingBookmark, name)
+	b.ShowBookmarksDrawer()
+}
+
+// RenameBookmark gives bookmark i a new name, if it is a good one
+func (b *Baize) RenameBookmark(i int, name string) {
+	if i < 0 || i >= len(b.bookmarks) {
+		return
+	}
+	name = strings.TrimSpace(name)
+	if err := b.checkBookmarkName(name, i); err != nil {
+		TheUI.Toast(err.Error())
+		sound.Play("Blip")
+		return
+	}
+	b.bookmarks[i].Name = name
+}
+
+// DeleteBookmark removes bookmark i
+func (b *Baize) DeleteBookmark(i int) {
+	if i < 0 || i >= len(b.bookmarks) {
+		return
+	}
+	b.bookmarks = append(b.bookmarks[:i], b.bookmarks[i+1:]...)
+}
+
+// ShowBookmarksDrawer lists the bookmarks, in the order they were made
+func (b *Baize) ShowBookmarksDrawer() {
+	renamingBookmark = -1
+	b.showBookmarksDrawer(-1)
+}
+
+// showBookmarksDrawer lists the bookmarks, with bookmark editing as a text input (or none, if -1)
+func (b *Baize) showBookmarksDrawer(editing int) {
+	var items []ui.BookmarkEntry
+	current := b.UndoPeek()
+	for i, bm := range b.bookmarks {
+		items = append(items, ui.BookmarkEntry{ID: strconv.Itoa(i), Name: bm.Name, Move: bm.Move,
+			Current: bm.State.SamePosition(current), Editing: i == editing, MaxLength: maxBookmarkName})
+	}
+	TheUI.ShowBookmarksDrawer(items)
+}
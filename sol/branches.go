@@ -0,0 +1,103 @@
+package sol
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/fogleman/gg"
+	"oddstream.games/gosol/ui"
+)
+
+const (
+	thumbnailWidth  = 96
+	thumbnailHeight = 64
+)
+
+// Thumbnail draws a miniature of the position sb, showing where the cards are but not what they are
+func (b *Baize) Thumbnail(sb *SavableBaize, width, height int) image.Image {
+	dc := gg.NewContext(width, height)
+	dc.SetColor(ExtendedColors[ThePreferences.BaizeColor])
+	dc.Clear()
+
+	slotW := float64(width) / float64(b.MaxSlotX()+1)
+	cardW := slotW * 0.8
+	cardH := cardW * ThePreferences.CardRatio
+	slotH := cardH * 1.1
+	fan := cardH / 5
+	for i, p := range b.piles {
+		if p.Hidden() || i >= len(sb.Piles) {
+			continue
+		}
+		x := float64(p.Slot().X)*slotW + float64(p.SlotShift().X)*slotW/100 + (slotW-cardW)/2
+		y := float64(p.Slot().Y)*slotH + float64(p.SlotShift().Y)*slotH/100
+		cards := sb.Piles[i].Cards
+		if len(cards) == 0 {
+			dc.SetRGBA(1, 1, 1, 0.25)
+			dc.DrawRectangle(x, y, cardW, cardH)
+			dc.Stroke()
+			continue
+		}
+		if p.FanType() != FAN_DOWN {
+			cards = cards[len(cards)-1:] // only the top card shows
+		}
+		for j, cid := range cards {
+			cy := y + float64(j)*fan
+			if cid.Prone() {
+				dc.SetColor(ExtendedColors[ThePreferences.CardBackColor])
+				dc.DrawRectangle(x, cy, cardW, cardH)
+				dc.Fill()
+			} else {
+				dc.SetColor(ExtendedColors[ThePreferences.CardFaceColor])
+				dc.DrawRectangle(x, cy, cardW, cardH)
+				dc.Fill()
+				// a stripe of suit color, so runs of alternating or matching colors can be seen
+				dc.SetColor(cid.Color())
+				dc.DrawRectangle(x, cy, cardW/3, fan)
+				dc.Fill()
+			}
+		}
+	}
+	return dc.Image()
+}
+
+// ShowBranchExplorer lists the interesting positions in the undo tree (the deal, branch points, branch ends
+// and the current position) in tree order, so the player can jump to any of them
+func (b *Baize) ShowBranchExplorer() {
+	current := b.undoNodeIndex(b.UndoPeek())
+	var nodes []ui.BranchNode
+	var walk func(n, depth, indent int)
+	walk = func(n, depth, indent int) {
+		children := b.undoChildren(n)
+		if n == 0 || n == current || len(children) != 1 {
+			var text string
+			switch {
+			case n == 0:
+				text = "Deal"
+			case len(children) == 0:
+				text = fmt.Sprintf("Move %d, branch end", depth)
+			case len(children) > 1:
+				text = fmt.Sprintf("Move %d, %d branches", depth, len(children))
+			default:
+				text = fmt.Sprintf("Move %d", depth)
+			}
+			nodes = append(nodes, ui.BranchNode{
+				Index:     n,
+				Indent:    indent,
+				Text:      text,
+				Thumbnail: b.Thumbnail(b.undoNodes[n].State, thumbnailWidth, thumbnailHeight),
+				Current:   n == current,
+			})
+		}
+		for i, c := range children {
+			if i == 0 {
+				walk(c, depth+1, indent) // the first line of play continues straight down
+			} else {
+				walk(c, depth+1, indent+1)
+			}
+		}
+	}
+	if len(b.undoNodes) > 0 {
+		walk(0, 0, 0)
+	}
+	TheUI.ShowBranchDrawer(nodes)
+}
@@ -0,0 +1,252 @@
+package sol
+
+//lint:file-ignore ST1005 Error messages are toasted, so need to be capitalized
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// A bundle is everything in a profile (preferences, statistics, and the saved games with their
+// undo trees and bookmarks) in one JSON file, for moving to another device or keeping as a backup.
+
+// bundleFormat identifies a bundle, so importing some other JSON file fails cleanly
+const bundleFormat = "gosol profile bundle"
+
+// Bundle is what gets exported and imported
+type Bundle struct {
+	Format      string
+	Version     int // see SchemaVersion
+	Profile     string
+	Device      string // DeviceID of the device the statistics were made on
+	Exported    time.Time
+	Preferences json.RawMessage `json:",omitempty"`
+	Statistics  json.RawMessage `json:",omitempty"`
+	SavedGames  json.RawMessage `json:",omitempty"`
+}
+
+// importedBundles holds bundles read by a file upload or picker, until the game loop can import them
+var importedBundles = make(chan []byte, 1)
+
+// ensureDeviceID gives this device an ID, if it hasn't already got one
+func ensureDeviceID() {
+	if ThePreferences.DeviceID == "" {
+		ThePreferences.DeviceID = newDeviceID()
+		ThePreferences.Save()
+	}
+}
+
+// bundleName is the file name suggested for an exported bundle
+func bundleName() string {
+	name := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '/' || r == '\\' {
+			return '-'
+		}
+		return r
+	}, TheProfiles.CurrentName())
+	return fmt.Sprintf("gosol-%s-%s.json", name, time.Now().Format("2006-01-02"))
+}
+
+// MakeBundle saves everything, then puts it into a bundle
+func MakeBundle() ([]byte, error) {
+	ensureDeviceID()
+	if !NoGameSave {
+		TheBaize.Save()
+	}
+	ThePreferences.Save()
+	TheStatistics.Save()
+	return bundleFrom(TheStorage, TheProfiles.CurrentName(), ThePreferences.DeviceID)
+}
+
+// bundleFrom puts the preferences, statistics and saved games in st into a bundle
+func bundleFrom(st Storage, profile string, device string) ([]byte, error) {
+	bundle := Bundle{
+		Format:   bundleFormat,
+		Version:  SchemaVersion,
+		Profile:  profile,
+		Device:   device,
+		Exported: time.Now().UTC(),
+	}
+	var err error
+	if bundle.Preferences, err = st.Load(preferencesKey); err != nil {
+		return nil, err
+	}
+	if bundle.Statistics, err = st.Load(statisticsKey); err != nil {
+		return nil, err
+	}
+	if bundle.SavedGames, err = st.Load(savedGamesKey); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(bundle, "", "\t")
+}
+
+// ExportBundle makes a bundle, and hands it to the platform to save or download
+func ExportBundle() {
+	bytes, err := MakeBundle()
+	if err != nil {
+		log.Println("ExportBundle", err)
+		TheUI.Toast("Could not export the profile")
+		return
+	}
+	where, err := exportBundle(bundleName(), bytes)
+	if err != nil {
+		log.Println("ExportBundle", err)
+		TheUI.Toast("Could not export the profile")
+		return
+	}
+	TheUI.Toast(fmt.Sprintf("Profile exported to %s", where))
+}
+
+// parseBundle checks everything in a bundle can be read, before any of it is used
+func parseBundle(bytes []byte) (*Bundle, *Preferences, *Statistics, *SavableGames, error) {
+	var bundle Bundle
+	if err := json.Unmarshal(bytes, &bundle); err != nil || bundle.Format != bundleFormat {
+		return nil, nil, nil, nil, errors.New("That is not a profile bundle")
+	}
+	if bundle.Version > SchemaVersion {
+		return nil, nil, nil, nil, errors.New("That bundle was made by a newer version")
+	}
+	var prefs *Preferences
+	if bundle.Preferences != nil {
+		// unmarshal with fresh maps, as the default maps would otherwise keep bindings the bundle doesn't have
+		prefs = DefaultPreferences()
+		prefs.GamepadButtons, prefs.KeyBindings = nil, nil
+		if err := json.Unmarshal(bundle.Preferences, prefs); err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("The preferences in that bundle are damaged")
+		}
+		prefs.GamepadButtons = withDefaultGamepadButtons(prefs.GamepadButtons)
+		prefs.KeyBindings = withDefaultKeyBindings(prefs.KeyBindings)
+	}
+	var stats *Statistics
+	if bundle.Statistics != nil {
+		stats = &Statistics{}
+		if err := json.Unmarshal(bundle.Statistics, stats); err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("The statistics in that bundle are damaged")
+		}
+		if stats.StatsMap == nil {
+			stats.StatsMap = make(map[string]*VariantStatistics)
+		}
+	}
+	var games *SavableGames
+	if bundle.SavedGames != nil {
+		var err error
+		if games, err = ParseSavedGames(bundle.SavedGames); err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("The games in that bundle are damaged")
+		}
+	}
+	return &bundle, prefs, stats, games, nil
+}
+
+// importStatistics adds the statistics from a bundle to s, as if they were synced from the device that made them
+func (s *Statistics) importStatistics(bundle *Bundle, stats *Statistics, deviceID string) error {
+	device := bundle.Device
+	if device == "" {
+		device = "import-" + bundle.Profile // from a device that never had an ID
+	}
+	m, err := stats.devices(device)
+	if err != nil {
+		return err
+	}
+	return s.takeDevices(deviceID, m)
+}
+
+// ImportBundle merges a bundle into the current profile. The preferences in the bundle replace
+// this profile's (except the ones that belong to this device), the statistics are added as if
+// they were synced from the device that made them (so importing the same bundle twice doesn't
+// count its games twice), and its games in progress are resumed for variants that have none here.
+func ImportBundle(bytes []byte) {
+	bundle, prefs, stats, games, err := parseBundle(bytes)
+	if err != nil {
+		TheUI.Toast(err.Error())
+		return
+	}
+	ensureDeviceID()
+
+	// the game in progress is put aside under its own variant, before the preferences can change the variant
+	TheBaize.putGameAside()
+
+	if prefs != nil {
+		prefs.Version = ThePreferences.Version
+		prefs.SyncURL, prefs.DeviceID = ThePreferences.SyncURL, ThePreferences.DeviceID
+		*ThePreferences = *prefs
+		applyPreferences()
+		if err := LoadKeyBindings(); err != nil {
+			TheUI.Toast(err.Error())
+		}
+		ThePreferences.Save()
+	}
+
+	if stats != nil {
+		if err := TheStatistics.importStatistics(bundle, stats, ThePreferences.DeviceID); err != nil {
+			log.Println("ImportBundle", err)
+			TheUI.Toast("The statistics in that bundle could not be merged")
+		} else {
+			TheStatistics.Save()
+		}
+	}
+
+	resumed := TheBaize.takeSavedGames(games)
+	// the preferences may have changed the variant
+	TheBaize.StartFreshGame()
+	TheBaize.resumeSavedGame()
+	TheUI.Toast(fmt.Sprintf("Imported %s, with %d games in progress", bundle.Profile, resumed))
+}
+
+// takeSavedGames adds the games from a bundle to the games put aside, for variants that have none here,
+// and returns how many were added
+func (b *Baize) takeSavedGames(games *SavableGames) int {
+	if games == nil {
+		return 0
+	}
+	var taken int
+	for _, tree := range games.Games {
+		if _, ok := b.savedGames[tree.Variant]; !ok {
+			b.savedGames[tree.Variant] = tree
+			taken++
+		}
+	}
+	return taken
+}
+
+// pendingBundle is a bundle that has been read, waiting for the player to say it should be imported
+var pendingBundle []byte
+
+// the choices offered before importing a bundle
+const (
+	importBundleChoice = "Import"
+	cancelBundleChoice = "Don't import"
+)
+
+// ImportPendingBundle asks about importing a bundle that has been read since the last Update
+func ImportPendingBundle() {
+	select {
+	case bytes := <-importedBundles:
+		askToImportBundle(bytes)
+	default:
+	}
+}
+
+// askToImportBundle says what is in a bundle, and what it will replace, and asks whether to import it
+func askToImportBundle(bytes []byte) {
+	bundle, _, _, _, err := parseBundle(bytes)
+	if err != nil {
+		TheUI.Toast(err.Error())
+		return
+	}
+	pendingBundle = bytes
+	TheUI.Toast(fmt.Sprintf("That is %s, exported %s; importing it replaces the preferences of %s",
+		bundle.Profile, bundle.Exported.Local().Format("2 Jan 2006 15:04"), TheProfiles.CurrentName()))
+	TheUI.ShowPicker("Import bundle", []string{importBundleChoice, cancelBundleChoice})
+}
+
+// FinishImportingBundle imports the pending bundle, if the player chose to
+func FinishImportingBundle(choice string) {
+	if choice == importBundleChoice && pendingBundle != nil {
+		ImportBundle(pendingBundle)
+	}
+	pendingBundle = nil
+}
@@ -0,0 +1,82 @@
+//go:build linux || windows || android
+
+package sol
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// bundleDir is where bundles are exported to and imported from; Downloads, if there is one, else the home directory
+func bundleDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	downloads := filepath.Join(home, "Downloads")
+	if fi, err := os.Stat(downloads); err == nil && fi.IsDir() {
+		return downloads, nil
+	}
+	return home, nil
+}
+
+// exportBundle writes the bundle to a file, returning where it went
+func exportBundle(name string, bytes []byte) (string, error) {
+	dir, err := bundleDir()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, name)
+	if err = os.WriteFile(path, bytes, 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// newestBundle returns the path of the most recently changed bundle in the bundle directory
+func newestBundle() (string, error) {
+	dir, err := bundleDir()
+	if err != nil {
+		return "", err
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "gosol-*.json"))
+	if err != nil {
+		return "", err
+	}
+	var newest string
+	var newestInfo os.FileInfo
+	for _, path := range paths {
+		fi, err := os.Stat(path)
+		if err != nil || fi.IsDir() {
+			continue
+		}
+		if newestInfo == nil || fi.ModTime().After(newestInfo.ModTime()) {
+			newest, newestInfo = path, fi
+		}
+	}
+	if newest == "" {
+		return "", errors.New("no bundle")
+	}
+	return newest, nil
+}
+
+// requestImportBundle reads the newest bundle (there are no file pickers here), for the game loop to ask about importing
+func requestImportBundle() {
+	path, err := newestBundle()
+	if err != nil {
+		dir, _ := bundleDir()
+		TheUI.Toast("No profile bundle (gosol-*.json) found in " + dir)
+		return
+	}
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		TheUI.Toast("Could not read " + path)
+		return
+	}
+	TheUI.Toast("Found " + filepath.Base(path))
+	select {
+	case importedBundles <- bytes:
+	default:
+	}
+}
@@ -0,0 +1,84 @@
+package sol
+
+import (
+	"encoding/json"
+	"image"
+	"testing"
+)
+
+func TestBundleRoundTrip(t *testing.T) {
+	phone := NewMemoryStorage()
+	playOn(t, phone, 2)
+	prefs := DefaultPreferences()
+	prefs.Variant = "Freecell"
+	bytes, err := json.Marshal(prefs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	phone.Save(preferencesKey, bytes)
+
+	exported, err := bundleFrom(phone, "Alice", "phone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle, imported, stats, _, err := parseBundle(exported)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bundle.Profile != "Alice" || imported == nil || imported.Variant != "Freecell" {
+		t.Errorf("bundle of %q has preferences %v", bundle.Profile, imported)
+	}
+
+	desktop := &Statistics{Revision: 1, StatsMap: map[string]*VariantStatistics{"Klondike": {Won: 1}}}
+	for i := 0; i < 2; i++ {
+		if err := desktop.importStatistics(bundle, stats, "desktop"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if won := desktop.variantTotals("Klondike").Won; won != 3 {
+		t.Errorf("importing twice should show 3 wins in total, not %d", won)
+	}
+
+	if _, _, _, _, err := parseBundle([]byte(`{"Format": "something else"}`)); err == nil {
+		t.Error("a file that is not a bundle should fail")
+	}
+	if _, _, _, _, err := parseBundle([]byte(`{"Format": "gosol profile bundle", "Statistics": "oops"}`)); err == nil {
+		t.Error("a bundle with damaged statistics should fail")
+	}
+}
+
+func TestImportBundleDuringGame(t *testing.T) {
+	saved := ThePreferences
+	defer func() { ThePreferences = saved }()
+	ThePreferences = DefaultPreferences()
+	ThePreferences.Variant = "Klondike"
+
+	// a Klondike game with a move made, and a card still in the stock so it is not complete
+	b := &Baize{}
+	stock := NewPile("Stock", image.Point{}, FAN_NONE, MOVE_ONE)
+	stock.vtable = &Stock{parent: &stock}
+	c := NewCard(0, 1, 1)
+	stock.cards = append(stock.cards, &c)
+	b.AddPile(&stock)
+	b.undoStack = append(b.undoStack, b.addUndoNode(&SavableBaize{}))
+	b.undoStack = append(b.undoStack, b.addUndoNode(&SavableBaize{Recycles: 1}))
+
+	// importing a bundle put aside for Freecell, with games for Freecell and Klondike
+	b.putGameAside()
+	ThePreferences.Variant = "Freecell"
+	games := &SavableGames{Games: []*SavableUndoTree{{Variant: "Freecell"}, {Variant: "Klondike"}}}
+	if taken := b.takeSavedGames(games); taken != 1 {
+		t.Errorf("took %d games from the bundle, expected only the Freecell one", taken)
+	}
+	if tree := b.savedGames["Klondike"]; tree == nil || len(tree.Path) != 2 {
+		t.Errorf("the Klondike game in progress was replaced, or lost: %v", tree)
+	}
+	if tree := b.savedGames["Freecell"]; tree == nil || tree != games.Games[0] {
+		t.Errorf("the Freecell game from the bundle was not taken: %v", tree)
+	}
+
+	// a bundle without games leaves the games put aside alone
+	if taken := b.takeSavedGames(nil); taken != 0 || b.savedGames["Klondike"] == nil {
+		t.Errorf("a bundle without games took %d, and left Klondike as %v", taken, b.savedGames["Klondike"])
+	}
+}
@@ -0,0 +1,53 @@
+package sol
+
+import (
+	"syscall/js"
+)
+
+// exportBundle makes the browser download the bundle
+func exportBundle(name string, bytes []byte) (string, error) {
+	document := js.Global().Get("document")
+	array := js.Global().Get("Uint8Array").New(len(bytes))
+	js.CopyBytesToJS(array, bytes)
+	blob := js.Global().Get("Blob").New([]interface{}{array}, map[string]interface{}{"type": "application/json"})
+	url := js.Global().Get("URL").Call("createObjectURL", blob)
+	defer js.Global().Get("URL").Call("revokeObjectURL", url)
+
+	a := document.Call("createElement", "a")
+	a.Set("href", url)
+	a.Set("download", name)
+	document.Get("body").Call("appendChild", a)
+	a.Call("click")
+	document.Get("body").Call("removeChild", a)
+	return name, nil
+}
+
+// requestImportBundle asks the browser for a file to upload; when it has been read, it is handed to the game loop
+func requestImportBundle() {
+	document := js.Global().Get("document")
+	input := document.Call("createElement", "input")
+	input.Set("type", "file")
+	input.Set("accept", ".json,application/json")
+
+	var onChange, onText js.Func
+	onText = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		defer onText.Release()
+		select {
+		case importedBundles <- []byte(args[0].String()):
+		default:
+		}
+		return nil
+	})
+	onChange = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		defer onChange.Release()
+		files := input.Get("files")
+		if files.Length() == 0 {
+			onText.Release()
+			return nil
+		}
+		files.Index(0).Call("text").Call("then", onText)
+		return nil
+	})
+	input.Call("addEventListener", "change", onChange)
+	input.Call("click")
+}
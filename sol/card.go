@@ -418,5 +418,9 @@ func (c *Card) Draw(screen *ebiten.Image) {
 		op.ColorM.Scale(0.9, 0.9, 0.9, 1)
 	}
 
+	if TheBaize.pairCard == c {
+		op.ColorM.Scale(0.75, 0.75, 0.75, 1)
+	}
+
 	screen.DrawImage(img, op)
 }
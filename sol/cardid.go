@@ -19,6 +19,10 @@ const (
 	DIAMOND_RUNE = rune(9830) // 0x2666
 	HEART_RUNE   = rune(9829) // 0x2665
 	SPADE_RUNE   = rune(9824) // 0x2660
+
+	// outlined red suits, used in high contrast mode
+	WHITE_DIAMOND_RUNE = rune(9826) // 0x2662
+	WHITE_HEART_RUNE   = rune(9825) // 0x2661
 )
 
 // CardID holds flags (prone &c), pack, suit, ordinal
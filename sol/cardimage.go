@@ -108,15 +108,23 @@ func createFaceImage(ID CardID) *ebiten.Image {
 	dc.Fill()
 
 	// surround with a thin border
-	dc.SetLineWidth(1)
-	// card face is probably light, so darken the border a bit
-	dc.SetRGBA(0, 0, 0, 0.1)
+	if ThePreferences.HighContrast {
+		dc.SetLineWidth(2)
+		dc.SetRGB(0, 0, 0)
+	} else {
+		dc.SetLineWidth(1)
+		// card face is probably light, so darken the border a bit
+		dc.SetRGBA(0, 0, 0, 0.1)
+	}
 	// draw the RoundedRect entirely INSIDE the context
 	dc.DrawRoundedRectangle(1, 1, w-2, h-2, CardCornerRadius)
 	dc.Stroke() // otherwise outline gets drawn in textColor (!?)
 
 	var cardOrdinal = ID.Ordinal()
 	var suitRune rune = ID.SuitRune()
+	if ThePreferences.HighContrast {
+		suitRune = highContrastRune(suitRune)
+	}
 	var cardColor color.RGBA = ID.Color()
 	if ID.Joker() {
 		// if a joker is pretending to be a certain card, then show it's pretend ordinal and suit, but faded
@@ -160,13 +168,21 @@ func createFaceImage(ID CardID) *ebiten.Image {
 			// dc.DrawStringAnchored(string(r), w*0.84, h*0.73, 0.5, 0.5)
 			dc.Stroke()
 
-			dc.SetRGBA(0, 0, 0, 0.05)
-			dc.DrawRectangle(w*0.25, h*0.25, w*0.5, h*0.5)
-			dc.Fill()
+			if cardOrdinal > 10 && ThePreferences.IllustratedCourtCards && !ID.Joker() {
+				drawCourtFigure(dc, ID, cardColor, w, h)
+			} else {
+				if ThePreferences.HighContrast {
+					drawSuitPattern(dc, ID.Suit(), cardColor, w*0.25, h*0.25, w*0.5, h*0.5)
+				} else {
+					dc.SetRGBA(0, 0, 0, 0.05)
+					dc.DrawRectangle(w*0.25, h*0.25, w*0.5, h*0.5)
+					dc.Fill()
+				}
 
-			dc.SetColor(cardColor)
-			dc.SetFontFace(schriftbank.CardSymbolLarge)
-			dc.DrawStringAnchored(string(suitRune), w*0.5, h*0.44, 0.5, 0.5)
+				dc.SetColor(cardColor)
+				dc.SetFontFace(schriftbank.CardSymbolLarge)
+				dc.DrawStringAnchored(string(suitRune), w*0.5, h*0.44, 0.5, 0.5)
+			}
 
 		} else if cardOrdinal > 0 {
 			// a blank joker will have an ordinal of zero
@@ -192,6 +208,44 @@ func createFaceImage(ID CardID) *ebiten.Image {
 	return ebiten.NewImageFromImage(dc.Image())
 }
 
+// highContrastRune swaps the red suits for their outlined symbols,
+// so they differ from the black suits in shape as well as color
+func highContrastRune(r rune) rune {
+	switch r {
+	case DIAMOND_RUNE:
+		return WHITE_DIAMOND_RUNE
+	case HEART_RUNE:
+		return WHITE_HEART_RUNE
+	}
+	return r
+}
+
+// drawSuitPattern fills a rectangle with faint lines whose direction depends on the suit:
+// vertical for clubs, rising for diamonds, falling for hearts, horizontal for spades
+func drawSuitPattern(dc *gg.Context, suit int, clr color.RGBA, x, y, w, h float64) {
+	dc.Push()
+	dc.DrawRectangle(x, y, w, h)
+	dc.Clip()
+	dc.SetColor(color.NRGBA{R: clr.R, G: clr.G, B: clr.B, A: 0x50})
+	dc.SetLineWidth(1)
+	gap := w / 8
+	for d := -h; d < w+h; d += gap {
+		switch suit {
+		case CLUB:
+			dc.DrawLine(x+d, y, x+d, y+h)
+		case DIAMOND:
+			dc.DrawLine(x+d, y+h, x+d+h, y)
+		case HEART:
+			dc.DrawLine(x+d, y, x+d+h, y+h)
+		case SPADE:
+			dc.DrawLine(x, y+d, x+w, y+d)
+		}
+	}
+	dc.Stroke()
+	dc.Pop()
+	dc.ResetClip() // Pop doesn't restore the clip
+}
+
 func CreateCardBackImage() *ebiten.Image {
 	w := float64(CardWidth)
 	h := float64(CardHeight)
@@ -203,8 +257,12 @@ func CreateCardBackImage() *ebiten.Image {
 	dc.Fill()
 
 	dc.SetLineWidth(2)
-	// card back probably dark, so lighten the border a bit
-	dc.SetRGBA(1, 1, 1, 0.1)
+	if ThePreferences.HighContrast {
+		dc.SetRGB(1, 1, 1)
+	} else {
+		// card back probably dark, so lighten the border a bit
+		dc.SetRGBA(1, 1, 1, 0.1)
+	}
 	// draw the RoundedRect entirely INSIDE the context
 	dc.DrawRoundedRectangle(1, 1, w-2, h-2, CardCornerRadius)
 	dc.Stroke() // otherwise outline gets drawn in textColor (!?)
@@ -238,6 +296,10 @@ func CreateCardFaceImageLibrary() {
 
 	for _, suit := range []int{NOSUIT, CLUB, DIAMOND, HEART, SPADE} {
 		for ord := 1; ord < 14; ord++ {
+			if img := TheCardTheme.faceImage(suit, ord); img != nil {
+				TheCardFaceImageLibrary[(suit*13)+(ord-1)] = img
+				continue
+			}
 			ID := NewCardID(0, suit, ord)
 			TheCardFaceImageLibrary[(suit*13)+(ord-1)] = createFaceImage(ID)
 		}
@@ -252,6 +314,8 @@ func CreateCardImages() {
 	// TODO MAYBE turn off drawing globally while this runs
 	schriftbank.MakeCardFonts(CardWidth)
 	CreateCardFaceImageLibrary()
-	CardBackImage = CreateCardBackImage()
+	if CardBackImage = TheCardTheme.backImage(); CardBackImage == nil {
+		CardBackImage = CreateCardBackImage()
+	}
 	CardShadowImage = CreateCardShadowImage()
 }
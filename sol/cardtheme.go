@@ -0,0 +1,320 @@
+package sol
+
+//lint:file-ignore ST1005 Error messages are toasted, so need to be capitalized
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/jpeg" // decode .jpg card images
+	_ "image/png"  // decode .png card images
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/fogleman/gg"
+	"github.com/hajimehoshi/ebiten/v2"
+	xdraw "golang.org/x/image/draw"
+	"oddstream.games/gosol/svg"
+	"oddstream.games/gosol/util"
+)
+
+// A card theme is a directory of images in the themes directory, which is next to the saved preferences.
+// The directory holds either
+//
+//   - one image per card, named by ordinal and suit like AS.png, 10H.svg or KD.jpg, and an optional back.png or back.svg, or
+//   - a sprite sheet, described by a theme.json file
+//
+// Cards missing from a theme are drawn in the usual way.
+
+// cardThemeSuits is the suit letters used in card image names, in suit order
+const cardThemeSuits = "?CDHS"
+
+// SpriteSheet describes a theme.json file; the sheet has a row per suit, and columns Ace to King
+type SpriteSheet struct {
+	Sheet      string  // name of the image file, relative to the theme directory
+	CardWidth  int     // size of each card in the sheet, in pixels
+	CardHeight int     //
+	Suits      string  // suit letters of the rows, top to bottom, default "CDHS"
+	Back       *[2]int `json:",omitempty"` // column and row of the card back, if it has one
+}
+
+// cardSource is a card image from a theme, which can be drawn at any size
+type cardSource interface {
+	render(width, height int) image.Image
+}
+
+// rasterSource is a bitmap image, which is scaled to size
+type rasterSource struct {
+	img image.Image
+}
+
+func (rs rasterSource) render(width, height int) image.Image {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	// Catmull-Rom is slow, but card images are only made when the card size changes
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), rs.img, rs.img.Bounds(), draw.Over, nil)
+	return dst
+}
+
+// vectorSource is an SVG image, which is drawn afresh at each size
+type vectorSource struct {
+	doc *svg.Document
+}
+
+func (vs vectorSource) render(width, height int) image.Image {
+	return vs.doc.Render(width, height)
+}
+
+// CardTheme is a set of card images loaded from a theme directory
+type CardTheme struct {
+	Name  string
+	faces [13 * 5]cardSource // indexed like TheCardFaceImageLibrary, nil for cards that are drawn
+	back  cardSource
+}
+
+// TheCardTheme is the theme chosen in ThePreferences, or nil to draw all the cards
+var TheCardTheme *CardTheme
+
+// cardThemeName is the name of a card image, without extension, eg "AS" or "10H"
+func cardThemeName(suit, ord int) string {
+	return util.OrdinalToShortString(ord) + cardThemeSuits[suit:suit+1]
+}
+
+// LoadCardTheme reads a theme from fsys, which is the theme's own directory
+func LoadCardTheme(name string, fsys fs.FS) (*CardTheme, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	// lower case file name without extension -> file name
+	files := map[string]string{}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		base := strings.ToLower(e.Name())
+		ext := path.Ext(base)
+		switch ext {
+		case ".json":
+			files[base] = e.Name()
+		case ".png", ".jpg", ".jpeg", ".svg":
+			base = strings.TrimSuffix(base, ext)
+			// prefer vector images when there are both
+			if prev, ok := files[base]; !ok || path.Ext(prev) != ".svg" {
+				files[base] = e.Name()
+			}
+		}
+	}
+
+	ct := &CardTheme{Name: name}
+	if sheet, ok := files["theme.json"]; ok {
+		err = ct.loadSpriteSheet(fsys, sheet)
+	} else {
+		err = ct.loadCardFiles(fsys, files)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if ct.back == nil && ct.empty() {
+		return nil, fmt.Errorf("Card theme '%s' has no card images", name)
+	}
+	return ct, nil
+}
+
+func (ct *CardTheme) empty() bool {
+	for _, src := range ct.faces {
+		if src != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// loadCardFiles reads one image per card
+func (ct *CardTheme) loadCardFiles(fsys fs.FS, files map[string]string) error {
+	for _, suit := range []int{CLUB, DIAMOND, HEART, SPADE} {
+		for ord := 1; ord < 14; ord++ {
+			if file, ok := files[strings.ToLower(cardThemeName(suit, ord))]; ok {
+				src, err := loadCardSource(fsys, file)
+				if err != nil {
+					return err
+				}
+				ct.faces[(suit*13)+(ord-1)] = src
+			}
+		}
+	}
+	if file, ok := files["back"]; ok {
+		src, err := loadCardSource(fsys, file)
+		if err != nil {
+			return err
+		}
+		ct.back = src
+	}
+	return nil
+}
+
+// loadSpriteSheet reads the cards from a single image, as described by a theme.json file
+func (ct *CardTheme) loadSpriteSheet(fsys fs.FS, file string) error {
+	bytes, err := fs.ReadFile(fsys, file)
+	if err != nil {
+		return err
+	}
+	var ss SpriteSheet
+	if err = json.Unmarshal(bytes, &ss); err != nil {
+		return fmt.Errorf("Card theme '%s': %s", ct.Name, err)
+	}
+	if ss.Suits == "" {
+		ss.Suits = "CDHS"
+	}
+	if ss.CardWidth <= 0 || ss.CardHeight <= 0 {
+		return fmt.Errorf("Card theme '%s' needs a card width and height", ct.Name)
+	}
+	sheet, err := decodeRaster(fsys, ss.Sheet)
+	if err != nil {
+		return err
+	}
+	sub, ok := sheet.(interface {
+		SubImage(r image.Rectangle) image.Image
+	})
+	if !ok {
+		return fmt.Errorf("Card theme '%s' sheet can't be cut into cards", ct.Name)
+	}
+	cell := func(col, row int) cardSource {
+		r := image.Rect(col*ss.CardWidth, row*ss.CardHeight, (col+1)*ss.CardWidth, (row+1)*ss.CardHeight).Add(sheet.Bounds().Min)
+		if !r.In(sheet.Bounds()) {
+			return nil
+		}
+		return rasterSource{img: sub.SubImage(r)}
+	}
+	for row, letter := range strings.ToUpper(ss.Suits) {
+		suit := strings.IndexRune(cardThemeSuits, letter)
+		if suit < CLUB {
+			return fmt.Errorf("Card theme '%s' has unknown suit '%c'", ct.Name, letter)
+		}
+		for ord := 1; ord < 14; ord++ {
+			if src := cell(ord-1, row); src != nil {
+				ct.faces[(suit*13)+(ord-1)] = src
+			}
+		}
+	}
+	if ss.Back != nil {
+		ct.back = cell(ss.Back[0], ss.Back[1])
+	}
+	return nil
+}
+
+func loadCardSource(fsys fs.FS, file string) (cardSource, error) {
+	if strings.EqualFold(path.Ext(file), ".svg") {
+		f, err := fsys.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		doc, err := svg.Parse(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", file, err)
+		}
+		return vectorSource{doc: doc}, nil
+	}
+	img, err := decodeRaster(fsys, file)
+	if err != nil {
+		return nil, err
+	}
+	return rasterSource{img: img}, nil
+}
+
+func decodeRaster(fsys fs.FS, file string) (image.Image, error) {
+	f, err := fsys.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", file, err)
+	}
+	return img, nil
+}
+
+// clipCardImage draws src at the card size, clipped to the card's rounded corners
+func clipCardImage(src cardSource) *ebiten.Image {
+	dc := gg.NewContext(CardWidth, CardHeight)
+	dc.DrawRoundedRectangle(0, 0, float64(CardWidth), float64(CardHeight), CardCornerRadius)
+	dc.Clip()
+	dc.DrawImage(src.render(CardWidth, CardHeight), 0, 0)
+	return ebiten.NewImageFromImage(dc.Image())
+}
+
+// faceImage returns the theme's image for a card, or nil if the card should be drawn
+func (ct *CardTheme) faceImage(suit, ord int) *ebiten.Image {
+	if ct == nil || ct.faces[(suit*13)+(ord-1)] == nil {
+		return nil
+	}
+	return clipCardImage(ct.faces[(suit*13)+(ord-1)])
+}
+
+// backImage returns the theme's card back, or nil if the back should be drawn
+func (ct *CardTheme) backImage() *ebiten.Image {
+	if ct == nil || ct.back == nil {
+		return nil
+	}
+	return clipCardImage(ct.back)
+}
+
+// CardThemeNames lists the installed themes, sorted
+func CardThemeNames() []string {
+	fsys := cardThemesFS()
+	if fsys == nil {
+		return nil
+	}
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// useCardTheme loads the named theme into TheCardTheme; an empty name, or a theme that won't load, means drawing the cards
+func useCardTheme(name string) error {
+	TheCardTheme = nil
+	if name == "" {
+		return nil
+	}
+	fsys := cardThemesFS()
+	if fsys == nil {
+		return errors.New("Card themes are not available here")
+	}
+	sub, err := fs.Sub(fsys, name)
+	if err != nil {
+		return err
+	}
+	ct, err := LoadCardTheme(name, sub)
+	if err != nil {
+		return err
+	}
+	TheCardTheme = ct
+	return nil
+}
+
+// drawnCardTheme is the card theme picker's entry for not using a theme
+const drawnCardTheme = "Drawn cards"
+
+// ShowCardThemePicker lists the installed card themes
+func ShowCardThemePicker() {
+	names := CardThemeNames()
+	if len(names) == 0 {
+		TheUI.Toast("No card themes are installed")
+		return
+	}
+	TheUI.ShowPicker("Card theme", append([]string{drawnCardTheme}, names...))
+}
@@ -0,0 +1,18 @@
+//go:build linux || windows || android
+
+package sol
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// cardThemesFS is the themes directory next to the saved preferences, or nil if there isn't one
+func cardThemesFS() fs.FS {
+	dir, err := storageDir()
+	if err != nil {
+		return nil
+	}
+	return os.DirFS(filepath.Join(dir, "themes"))
+}
@@ -0,0 +1,8 @@
+package sol
+
+import "io/fs"
+
+// cardThemesFS is nil; there is no file system in the browser to install themes in
+func cardThemesFS() fs.FS {
+	return nil
+}
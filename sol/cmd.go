@@ -5,24 +5,24 @@ import (
 	"log"
 	"strconv"
 
-	"github.com/hajimehoshi/ebiten/v2"
-	"oddstream.games/gosol/sound"
 	"oddstream.games/gosol/ui"
 )
 
-var CommandTable = map[ebiten.Key]func(){
-	ebiten.Key2: func() { ThePreferences.FourColors = false; TheBaize.setFlag(dirtyCardImages) },
-	ebiten.Key4: func() { ThePreferences.FourColors = true; TheBaize.setFlag(dirtyCardImages) },
-	ebiten.KeyN: func() { TheBaize.NewDeal() },
-	ebiten.KeyR: func() { TheBaize.RestartDeal() },
-	ebiten.KeyU: func() { TheBaize.Undo() },
-	ebiten.KeyS: func() { TheBaize.SavePosition() },
-	ebiten.KeyL: func() { TheBaize.LoadPosition() },
-	ebiten.KeyC: func() { TheBaize.Collect() },
-	ebiten.KeyH: func() { TheBaize.showMovableCards = !TheBaize.showMovableCards },
-	ebiten.KeyF: func() { TheBaize.ShowVariantGroupPicker() },
-	ebiten.KeyX: func() { ExitRequested = true },
-	ebiten.KeyTab: func() {
+// CommandTable maps command names (sent by the UI, or looked up from the key bindings) to functions
+var CommandTable = map[ui.Command]func(){
+	"NewDeal":      func() { TheBaize.NewDeal() },
+	"RestartDeal":  func() { TheBaize.RestartDeal() },
+	"Undo":         func() { TheBaize.Undo() },
+	"Bookmark":     func() { TheBaize.SavePosition() },
+	"GotoBookmark": func() { TheBaize.LoadPosition() },
+	"Bookmarks":    func() { TheBaize.ShowBookmarksDrawer() },
+	"NewBookmark":  func() { TheBaize.NewBookmark() },
+	"Branches":     func() { TheBaize.ShowBranchExplorer() },
+	"Collect":      func() { TheBaize.Collect() },
+	"Hint":         func() { TheBaize.showMovableCards = !TheBaize.showMovableCards },
+	"FindGame":     func() { TheBaize.ShowVariantGroupPicker() },
+	"Exit":         func() { ExitRequested = true },
+	"Refan": func() {
 		if DebugMode {
 			for _, p := range TheBaize.piles {
 				p.Refan()
@@ -30,27 +30,39 @@ var CommandTable = map[ebiten.Key]func(){
 			ThePreferences.Save()
 		}
 	},
-	ebiten.KeyF1:     func() { TheBaize.Wikipedia() },
-	ebiten.KeyF2:     func() { TheStatistics.WelcomeToast(TheBaize.LongVariantName()) },
-	ebiten.KeyF3:     func() { ShowSettingsDrawer() },
-	ebiten.KeyF5:     func() { TheBaize.StartSpinning() },
-	ebiten.KeyF6:     func() { TheBaize.StopSpinning() },
-	ebiten.KeyF8:     func() { TheUI.HideFAB() },
-	ebiten.KeyMenu:   func() { TheUI.ToggleNavDrawer() },
-	ebiten.KeyEscape: func() { TheUI.HideActiveDrawer() },
+	"Wikipedia":   func() { TheBaize.Wikipedia() },
+	"Statistics":  func() { TheStatistics.WelcomeToast(TheBaize.LongVariantName()) },
+	"Settings":    func() { ShowSettingsDrawer() },
+	"KeyBindings": func() { ShowKeyBindingsDrawer() },
+	"CardThemes":  func() { ShowCardThemePicker() },
+	"Profiles":    func() { ShowProfilesDrawer() },
+	"NewProfile":  func() { CreateProfile(TheProfiles.nextProfileName()) },
+	"Export":      func() { ExportBundle() },
+	"Import":      func() { requestImportBundle() },
+	"Spin":        func() { TheBaize.StartSpinning() },
+	"StopSpin":    func() { TheBaize.StopSpinning() },
+	"HideFAB":     func() { TheUI.HideFAB() },
+	"Menu":        func() { TheUI.ToggleNavDrawer() },
+	"Cancel":      func() { TheUI.HideActiveDrawer(); TheBaize.ClearFocus() },
 }
 
 func Execute(cmd interface{}) {
 	switch v := cmd.(type) {
-	case ebiten.Key:
+	case ui.Command:
 		if fn, ok := CommandTable[v]; ok {
 			TheUI.HideActiveDrawer()
 			TheUI.HideFAB()
 			fn()
+		} else {
+			log.Println("unknown command", v)
 		}
 
 	case ui.ChangeRequest:
 		// a widget has sent a change request
+		if changeSetting(v.ChangeRequested, v.Data) {
+			// settings change in place, leaving the settings drawer open
+			return
+		}
 		TheUI.HideActiveDrawer()
 		TheUI.HideFAB()
 		switch v.ChangeRequested {
@@ -64,26 +76,52 @@ func Execute(cmd interface{}) {
 			}
 		case "VariantGroup":
 			TheBaize.ShowVariantPicker(v.Data)
-		case "Fixed cards":
-			ThePreferences.FixedCards, _ = strconv.ParseBool(v.Data)
-			TheBaize.setFlag(dirtyCardSizes | dirtyPileBackgrounds | dirtyPilePositions | dirtyCardPositions)
-		case "Power moves":
-			ThePreferences.PowerMoves, _ = strconv.ParseBool(v.Data)
-		case "Four colors":
-			ThePreferences.FourColors, _ = strconv.ParseBool(v.Data)
-			TheBaize.setFlag(dirtyCardImages)
-		case "Mirror baize":
-			ThePreferences.MirrorBaize, _ = strconv.ParseBool(v.Data)
-			savedUndoStack := TheBaize.undoStack
-			TheBaize.StartFreshGame()
-			TheBaize.SetUndoStack(savedUndoStack)
-		case "Mute sounds":
-			ThePreferences.Mute, _ = strconv.ParseBool(v.Data)
-			if ThePreferences.Mute {
-				sound.SetVolume(0.0)
+		case "Find variant":
+			TheBaize.FindVariant(v.Data)
+		case "Card theme":
+			if v.Data == drawnCardTheme {
+				ThePreferences.CardTheme = ""
 			} else {
-				sound.SetVolume(ThePreferences.Volume)
+				ThePreferences.CardTheme = v.Data
+			}
+			applyPreferences()
+			if TheCardTheme == nil {
+				ThePreferences.CardTheme = ""
+			}
+		case "Goto position":
+			if n, err := strconv.Atoi(v.Data); err == nil {
+				TheBaize.GotoUndoNode(n)
+			}
+		case "Goto bookmark":
+			if i, err := strconv.Atoi(v.Data); err == nil {
+				TheBaize.TapBookmark(i)
+			}
+		case "Rename bookmark":
+			TheBaize.FinishRenamingBookmark(v.Data)
+		case "Delete bookmark":
+			if i, err := strconv.Atoi(v.Data); err == nil {
+				TheBaize.DeleteBookmark(i)
+				TheBaize.ShowBookmarksDrawer()
+			}
+		case "Switch profile":
+			if i, err := strconv.Atoi(v.Data); err == nil {
+				if i == TheProfiles.Current {
+					StartRenamingProfile(i)
+				} else {
+					SwitchProfile(i)
+				}
+			}
+		case "Rename profile":
+			FinishRenamingProfile(v.Data)
+		case "Delete profile":
+			if i, err := strconv.Atoi(v.Data); err == nil {
+				DeleteProfile(i)
+				ShowProfilesDrawer()
 			}
+		case "Import bundle":
+			FinishImportingBundle(v.Data)
+		case "Key binding":
+			StartRebinding(v.Data)
 		default:
 			log.Panic("unknown change request", v.ChangeRequested, v.Data)
 		}
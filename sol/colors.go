@@ -121,5 +121,93 @@ var ExtendedColors = map[string]color.RGBA{
 	"Snow":        {R: 0xFA, G: 0xFA, B: 0xFA, A: 0xff},
 	"Ivory":       {R: 0xFF, G: 0xFF, B: 0xF0, A: 0xff},
 	"White":       {R: 0xFF, G: 0xFF, B: 0xFF, A: 0xff},
+	// Okabe-Ito colors, which can be told apart with any kind of color blindness
+	// https://jfly.uni-koeln.de/color/
+	"OkabeOrange":        {R: 0xE6, G: 0x9F, B: 0x00, A: 0xff},
+	"OkabeSkyBlue":       {R: 0x56, G: 0xB4, B: 0xE9, A: 0xff},
+	"OkabeBluishGreen":   {R: 0x00, G: 0x9E, B: 0x73, A: 0xff},
+	"OkabeYellow":        {R: 0xF0, G: 0xE4, B: 0x42, A: 0xff},
+	"OkabeBlue":          {R: 0x00, G: 0x72, B: 0xB2, A: 0xff},
+	"OkabeVermillion":    {R: 0xD5, G: 0x5E, B: 0x00, A: 0xff},
+	"OkabeReddishPurple": {R: 0xCC, G: 0x79, B: 0xA7, A: 0xff},
 	// TODO complete this map with all extended colors
 } // golang gotcha no newline after last literal, must be comma or closing brace
+
+// Palette is a named set of ExtendedColors for the baize, piles and cards
+type Palette struct {
+	Baize, CardFace, CardBack   string // copied into Preferences when the palette is chosen
+	Black, Red                  string // card colors in two colors
+	Club, Diamond, Heart, Spade string // card colors in four colors
+	PileOutline, DropTarget     string // drawn straight from the palette
+}
+
+// PaletteNames lists the palettes in the order they are offered
+var PaletteNames = []string{"Standard", "Deuteranopia", "Protanopia", "Tritanopia"}
+
+// Palettes are the preset color schemes. Apart from Standard, they avoid pairs of colors
+// that differ only in hue along the axis that players with that kind of color blindness can't see,
+// and differ in lightness as well where they can
+var Palettes = map[string]Palette{
+	"Standard": {
+		Baize: "BaizeGreen", CardFace: "Ivory", CardBack: "CornflowerBlue",
+		Black: "Black", Red: "Crimson",
+		Club: "DarkGreen", Diamond: "DarkBlue", Heart: "Crimson", Spade: "Black",
+		PileOutline: "White", DropTarget: "LimeGreen",
+	},
+	// red-green, weak green
+	"Deuteranopia": {
+		Baize: "DarkSlateGray", CardFace: "Ivory", CardBack: "OkabeBlue",
+		Black: "Black", Red: "OkabeVermillion",
+		Club: "OkabeBlue", Diamond: "OkabeOrange", Heart: "OkabeVermillion", Spade: "Black",
+		PileOutline: "OkabeSkyBlue", DropTarget: "OkabeYellow",
+	},
+	// red-green, weak red, so reds look dark
+	"Protanopia": {
+		Baize: "DarkSlateGray", CardFace: "Ivory", CardBack: "OkabeBlue",
+		Black: "Black", Red: "OkabeOrange",
+		Club: "OkabeBlue", Diamond: "OkabeBluishGreen", Heart: "OkabeOrange", Spade: "Black",
+		PileOutline: "OkabeSkyBlue", DropTarget: "OkabeYellow",
+	},
+	// blue-yellow
+	"Tritanopia": {
+		Baize: "MidnightBlue", CardFace: "Ivory", CardBack: "Firebrick",
+		Black: "Black", Red: "OkabeVermillion",
+		Club: "OkabeBlue", Diamond: "OkabeReddishPurple", Heart: "OkabeVermillion", Spade: "Black",
+		PileOutline: "White", DropTarget: "OkabeVermillion",
+	},
+}
+
+// ApplyPalette copies the colors of the named palette into the preferences
+func (prefs *Preferences) ApplyPalette(name string) bool {
+	pal, ok := Palettes[name]
+	if !ok {
+		return false
+	}
+	prefs.Palette = name
+	prefs.BaizeColor, prefs.CardFaceColor, prefs.CardBackColor = pal.Baize, pal.CardFace, pal.CardBack
+	prefs.BlackColor, prefs.RedColor = pal.Black, pal.Red
+	prefs.ClubColor, prefs.DiamondColor, prefs.HeartColor, prefs.SpadeColor = pal.Club, pal.Diamond, pal.Heart, pal.Spade
+	return true
+}
+
+// currentPalette is the palette last chosen, or Standard
+func currentPalette() Palette {
+	if pal, ok := Palettes[ThePreferences.Palette]; ok {
+		return pal
+	}
+	return Palettes["Standard"]
+}
+
+// pileOutlineColor is faint, so empty piles don't distract, unless in high contrast mode
+func pileOutlineColor() color.Color {
+	c := ExtendedColors[currentPalette().PileOutline]
+	if ThePreferences.HighContrast {
+		return c
+	}
+	return color.NRGBA{R: c.R, G: c.G, B: c.B, A: 31}
+}
+
+// dropTargetColor is used to outline piles that will accept the cards being dragged
+func dropTargetColor() color.Color {
+	return ExtendedColors[currentPalette().DropTarget]
+}
@@ -0,0 +1,162 @@
+package sol
+
+import (
+	"image/color"
+
+	"github.com/fogleman/gg"
+	"oddstream.games/gosol/schriftbank"
+)
+
+var (
+	courtSkinColor  = color.RGBA{R: 0xff, G: 0xe4, B: 0xc4, A: 0xff} // Bisque
+	courtHairColor  = color.RGBA{R: 0x8b, G: 0x45, B: 0x13, A: 0xff} // SaddleBrown
+	courtBeardColor = color.RGBA{R: 0xa9, G: 0xa9, B: 0xa9, A: 0xff} // DarkGray
+	courtGoldColor  = color.RGBA{R: 0xda, G: 0xa5, B: 0x20, A: 0xff} // Goldenrod
+)
+
+// drawCourtFigure draws a Jack, Queen or King in the middle of a card face, as a double ended bust
+// (like a real court card), so it can be spotted in a crowded tableau and reads the same either way up
+func drawCourtFigure(dc *gg.Context, ID CardID, cardColor color.RGBA, w, h float64) {
+	// the frame is inside the corner indexes
+	x0, y0, x1, y1 := w*0.22, h*0.2, w*0.78, h*0.8
+	cx, cy := w*0.5, h*0.5
+
+	if ThePreferences.HighContrast {
+		drawSuitPattern(dc, ID.Suit(), cardColor, x0, y0, x1-x0, y1-y0)
+	} else {
+		dc.SetColor(color.NRGBA{R: cardColor.R, G: cardColor.G, B: cardColor.B, A: 0x14})
+		dc.DrawRectangle(x0, y0, x1-x0, y1-y0)
+		dc.Fill()
+	}
+
+	for half := 0; half < 2; half++ {
+		dc.Push()
+		if half == 1 {
+			dc.RotateAbout(gg.Radians(180), cx, cy)
+		}
+		// keep each half of the figure to its own half of the frame
+		dc.DrawRectangle(x0, y0, x1-x0, cy-y0)
+		dc.Clip()
+		drawCourtBust(dc, ID, cardColor, cx, cy, (x1-x0)/2, cy-y0)
+		dc.Pop()
+		dc.ResetClip() // Pop doesn't restore the clip
+	}
+
+	dc.SetColor(cardColor)
+	dc.SetLineWidth(1)
+	dc.DrawLine(x0, cy, x1, cy)
+	dc.Stroke()
+	dc.DrawRectangle(x0, y0, x1-x0, y1-y0)
+	dc.Stroke()
+}
+
+// drawCourtBust draws the top half of a court figure, standing on (cx, cy);
+// W is the half width of the frame and H its half height, so the figure scales with the card
+func drawCourtBust(dc *gg.Context, ID CardID, cardColor color.RGBA, cx, cy, W, H float64) {
+	ord := ID.Ordinal()
+	outline := func() {
+		dc.SetColor(cardColor)
+		dc.SetLineWidth(1)
+		dc.Stroke()
+	}
+	headY, headR := cy-0.62*H, 0.2*H
+
+	// the Queen's hair goes behind her head and shoulders
+	if ord == 12 {
+		dc.DrawEllipse(cx, headY+0.1*H, 0.26*H, 0.32*H)
+		dc.SetColor(courtHairColor)
+		dc.Fill()
+	}
+
+	// robe, in the suit color, with the suit on the chest
+	dc.MoveTo(cx-0.85*W, cy)
+	dc.LineTo(cx-0.85*W, cy-0.2*H)
+	dc.QuadraticTo(cx-0.8*W, cy-0.4*H, cx-0.3*W, cy-0.42*H)
+	dc.LineTo(cx+0.3*W, cy-0.42*H)
+	dc.QuadraticTo(cx+0.8*W, cy-0.4*H, cx+0.85*W, cy-0.2*H)
+	dc.LineTo(cx+0.85*W, cy)
+	dc.ClosePath()
+	dc.SetColor(cardColor)
+	dc.Fill()
+
+	dc.MoveTo(cx-0.25*W, cy-0.42*H)
+	dc.LineTo(cx+0.25*W, cy-0.42*H)
+	dc.LineTo(cx, cy-0.22*H)
+	dc.ClosePath()
+	dc.SetColor(ExtendedColors[ThePreferences.CardFaceColor])
+	dc.Fill()
+
+	if r := ID.SuitRune(); r != 0 {
+		if ThePreferences.HighContrast {
+			r = highContrastRune(r)
+		}
+		dc.SetFontFace(schriftbank.CardSymbolSmall)
+		dc.SetColor(ExtendedColors[ThePreferences.CardFaceColor])
+		dc.DrawStringAnchored(string(r), cx-0.5*W, cy-0.16*H, 0.5, 0.5)
+	}
+
+	// head
+	dc.DrawCircle(cx, headY, headR)
+	dc.SetColor(courtSkinColor)
+	dc.FillPreserve()
+	outline()
+
+	if ord == 13 {
+		// the King has a beard
+		dc.MoveTo(cx-headR*0.9, headY+headR*0.2)
+		dc.QuadraticTo(cx, headY+headR*2.1, cx+headR*0.9, headY+headR*0.2)
+		dc.QuadraticTo(cx, headY+headR*0.7, cx-headR*0.9, headY+headR*0.2)
+		dc.SetColor(courtBeardColor)
+		dc.FillPreserve()
+		outline()
+	}
+
+	// eyes
+	dc.SetRGB(0, 0, 0)
+	dc.DrawCircle(cx-headR*0.4, headY-headR*0.1, headR*0.12)
+	dc.DrawCircle(cx+headR*0.4, headY-headR*0.1, headR*0.12)
+	dc.Fill()
+
+	// headwear
+	top := headY - headR*0.7
+	switch ord {
+	case 11:
+		// a cap with a feather
+		dc.MoveTo(cx+headR*0.5, top-headR*0.2)
+		dc.QuadraticTo(cx+headR*1.6, top-headR*0.6, cx+headR*1.9, top-headR*1.4)
+		dc.SetColor(courtGoldColor)
+		dc.SetLineWidth(headR * 0.25)
+		dc.Stroke()
+		dc.DrawEllipse(cx, top, headR*1.15, headR*0.45)
+		dc.SetColor(cardColor)
+		dc.Fill()
+	case 12:
+		// a tiara with three jewels
+		dc.MoveTo(cx-headR*0.8, top+headR*0.2)
+		dc.LineTo(cx-headR*0.6, top-headR*0.4)
+		dc.LineTo(cx+headR*0.6, top-headR*0.4)
+		dc.LineTo(cx+headR*0.8, top+headR*0.2)
+		dc.ClosePath()
+		dc.SetColor(courtGoldColor)
+		dc.FillPreserve()
+		outline()
+		for _, dx := range []float64{-0.5, 0, 0.5} {
+			dc.DrawCircle(cx+headR*dx, top-headR*0.55, headR*0.18)
+		}
+		dc.SetColor(cardColor)
+		dc.Fill()
+	case 13:
+		// a crown with three points
+		dc.MoveTo(cx-headR*0.9, top+headR*0.2)
+		dc.LineTo(cx-headR*1.05, top-headR*0.9)
+		dc.LineTo(cx-headR*0.5, top-headR*0.4)
+		dc.LineTo(cx, top-headR*1.1)
+		dc.LineTo(cx+headR*0.5, top-headR*0.4)
+		dc.LineTo(cx+headR*1.05, top-headR*0.9)
+		dc.LineTo(cx+headR*0.9, top+headR*0.2)
+		dc.ClosePath()
+		dc.SetColor(courtGoldColor)
+		dc.FillPreserve()
+		outline()
+	}
+}
@@ -12,6 +12,11 @@ func (b *Baize) FindHomesForTail(tail []*Card) []*Pile {
 		return homes
 	}
 
+	// in pair removal games, cards go to the Discard, or onto a card they pair with
+	if ps, ok := b.script.(PairScriptInterface); ok && !src.IsStock() {
+		return b.findPairHomes(ps, card)
+	}
+
 	// is the tail conformant enough to move?
 	if ok, _ := b.script.TailMoveError(tail); !ok {
 		return homes
@@ -26,6 +31,14 @@ func (b *Baize) FindHomesForTail(tail []*Card) []*Pile {
 		// in Go 1.17, nil was not appended
 		pilesToCheck = append(pilesToCheck, b.script.Waste())
 	}
+	if b.TwoPlayer() {
+		// the other player's piles, which a LoadingScriptInterface may allow cards to be loaded onto
+		for _, p := range b.piles {
+			if p.player != 0 && p.player != b.turn && p != b.script.Waste() {
+				pilesToCheck = append(pilesToCheck, p)
+			}
+		}
+	}
 
 	for _, dst := range pilesToCheck {
 		if !dst.Valid() {
@@ -72,13 +85,16 @@ func (b *Baize) FindDestinations() {
 		b.moves += len(card.destinations)
 	}
 
+	// in positional games, moving a card to an empty pile changes it's neighbours, so is never pointless
+	_, positional := b.script.(PositionalScriptInterface)
+
 	for _, mc := range b.findAllMovableTails() {
 		movable := true
 		card := mc.tail[0]
 		src := card.owner
 		dst := mc.dst
 		// moving an full tail from one pile to another empty pile is pointless
-		if dst.Len() == 0 && len(mc.tail) == len(src.cards) {
+		if !positional && dst.Len() == 0 && len(mc.tail) == len(src.cards) {
 			if src.label == dst.label && src.category == dst.category {
 				movable = false
 			}
@@ -98,23 +114,43 @@ type PileAndWeight struct {
 	weight int
 }
 
-func (b *Baize) BestDestination(card *Card, destinations []*Pile) *Pile {
+// DefaultDestinationWeight prefers foundations, then piles with a card of the same suit, then bigger piles
+func DefaultDestinationWeight(card *Card, dst *Pile) int {
+	var weight int = len(dst.cards)
+	switch dst.category {
+	case "Foundation":
+		weight += 52 // magic number, sorry
+	case "Tableau":
+		if len(dst.cards) > 0 {
+			if card.Suit() == dst.Peek().Suit() {
+				weight += 26 // magic number, sorry
+			}
+		}
+	}
+	return weight
+}
+
+// RankDestinations sorts destinations for card, best first, using the script's weights if it has any
+func (b *Baize) RankDestinations(card *Card, destinations []*Pile) []*Pile {
 	var paw []*PileAndWeight
 	for _, dst := range destinations {
-		var tmp PileAndWeight = PileAndWeight{pile: dst, weight: len(dst.cards)}
-
-		switch dst.category {
-		case "Foundation":
-			tmp.weight += 52 // magic number, sorry
-		case "Tableau":
-			if len(dst.cards) > 0 {
-				if card.Suit() == dst.Peek().Suit() {
-					tmp.weight += 26 // magic number, sorry
-				}
-			}
+		var tmp PileAndWeight = PileAndWeight{pile: dst}
+		if ws, ok := b.script.(WeightedScriptInterface); ok {
+			tmp.weight = ws.DestinationWeight(card, dst)
+		} else {
+			tmp.weight = DefaultDestinationWeight(card, dst)
 		}
 		paw = append(paw, &tmp)
 	}
-	sort.Slice(paw, func(i, j int) bool { return paw[i].weight > paw[j].weight })
-	return paw[0].pile
+	// stable, so that equally weighted piles are offered left to right
+	sort.SliceStable(paw, func(i, j int) bool { return paw[i].weight > paw[j].weight })
+	var ranked []*Pile
+	for _, pw := range paw {
+		ranked = append(ranked, pw.pile)
+	}
+	return ranked
+}
+
+func (b *Baize) BestDestination(card *Card, destinations []*Pile) *Pile {
+	return b.RankDestinations(card, destinations)[0]
 }
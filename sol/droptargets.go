@@ -0,0 +1,77 @@
+package sol
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+// FindDropTargets remembers the piles that would accept the tail being dragged,
+// so they can be highlighted, and so a drop can snap to them
+func (b *Baize) FindDropTargets() {
+	b.dropTargets = nil
+	if len(b.tail) > 0 && (ThePreferences.ShowDropTargets || ThePreferences.SnapDrops) {
+		b.dropTargets = b.FindHomesForTail(b.tail)
+	}
+}
+
+func (b *Baize) isDropTarget(p *Pile) bool {
+	for _, dt := range b.dropTargets {
+		if dt == p {
+			return true
+		}
+	}
+	return false
+}
+
+// distanceToRect returns how far pt is outside r, or zero if it's inside
+func distanceToRect(pt image.Point, r image.Rectangle) float64 {
+	dx := math.Max(math.Max(float64(r.Min.X-pt.X), 0), float64(pt.X-r.Max.X))
+	dy := math.Max(math.Max(float64(r.Min.Y-pt.Y), 0), float64(pt.Y-r.Max.Y))
+	return math.Hypot(dx, dy)
+}
+
+// SnapDropTarget returns the drop target nearest to the dragged card c, if it's within a card width,
+// and nearer than the pile the card came from (in which case the card is probably being put back)
+func (b *Baize) SnapDropTarget(c *Card) *Pile {
+	r := c.BaizeRect()
+	centre := r.Min.Add(r.Max).Div(2)
+	var nearest *Pile
+	nearestDist := float64(CardWidth)
+	for _, dt := range b.dropTargets {
+		if d := distanceToRect(centre, dt.FannedBaizeRect()); d < nearestDist {
+			nearest, nearestDist = dt, d
+		}
+	}
+	if nearest != nil && distanceToRect(centre, c.Owner().FannedBaizeRect()) < nearestDist {
+		return nil
+	}
+	return nearest
+}
+
+// drawOutline draws a rectangle around r
+func drawOutline(screen *ebiten.Image, r image.Rectangle, clr color.Color) {
+	x, y, w, h := float64(r.Min.X), float64(r.Min.Y), float64(r.Dx()), float64(r.Dy())
+	const t = 3 // thickness
+	ebitenutil.DrawRect(screen, x-t, y-t, w+t*2, t, clr)
+	ebitenutil.DrawRect(screen, x-t, y+h, w+t*2, t, clr)
+	ebitenutil.DrawRect(screen, x-t, y, t, h, clr)
+	ebitenutil.DrawRect(screen, x+w, y, t, h, clr)
+}
+
+// drawDropTargets outlines the top card (or the empty pile) of each pile that would accept the tail being dragged
+func (b *Baize) drawDropTargets(screen *ebiten.Image) {
+	if !ThePreferences.ShowDropTargets {
+		return
+	}
+	for _, dt := range b.dropTargets {
+		if c := dt.Peek(); c != nil {
+			drawOutline(screen, c.ScreenRect(), dropTargetColor())
+		} else {
+			drawOutline(screen, dt.ScreenRect(), dropTargetColor())
+		}
+	}
+}
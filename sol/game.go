@@ -3,9 +3,9 @@ package sol
 
 import (
 	"errors"
+	"sync"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"oddstream.games/gosol/sound"
 	"oddstream.games/gosol/ui"
 )
 
@@ -70,16 +70,24 @@ var CardLibrary []Card
 
 // NewGame generates a new Game object.
 func NewGame() (*Game, error) {
-	ThePreferences.Load()
-	if ThePreferences.Mute {
-		sound.SetVolume(0.0)
-	} else {
-		sound.SetVolume(ThePreferences.Volume)
+	if TheStorage == nil {
+		TheStorage = defaultStorage()
 	}
+	loadProfiles()
+	ThePreferences.Load()
+	startSync()
+	applyPreferences()
 	TheUI = ui.New(Execute)
+	toastLoadProblems()
+	if err := LoadKeyBindings(); err != nil {
+		TheUI.Toast(err.Error())
+	}
 	TheStatistics = NewStatistics()
 	TheBaize = NewBaize()
 	TheBaize.StartFreshGame()
+	// keep updating while the window is unfocused, so checkFocusForAutosave sees the focus go;
+	// browsers and Android stop the game loop regardless, so they call Suspend instead
+	ebiten.SetRunnableOnUnfocused(true)
 	return &Game{}, nil
 }
 
@@ -89,8 +97,14 @@ func (*Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight
 	return outsideWidth, outsideHeight
 }
 
+// updating is held while the game state is updated, so Suspend, which is called from outside the game loop,
+// sees a consistent game
+var updating sync.Mutex
+
 // Update updates the current game state.
 func (*Game) Update() error {
+	updating.Lock()
+	defer updating.Unlock()
 	TheBaize.Update()
 	if ExitRequested {
 		if !NoGameSave {
@@ -0,0 +1,105 @@
+package sol
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"oddstream.games/gosol/input"
+	"oddstream.games/gosol/ui"
+)
+
+// Gamepad play; the left stick moves a virtual cursor that taps and drags like a mouse,
+// the d-pad moves the pile focus like the arrow keys, and the other buttons are mapped
+// to commands by Preferences.GamepadButtons, using ebiten's standard gamepad layout.
+
+// GamepadCommands maps the command names used in Preferences.GamepadButtons to functions
+var GamepadCommands = map[string]func(){
+	"PickUpOrDrop": func() {
+		// when the cursor is visible, this button starts a stroke instead (see input.GamepadStrokeButton)
+		if !input.TheGamepadCursor.Visible() {
+			TheBaize.PickUpOrDrop()
+		}
+	},
+	"PutBack":    func() { Execute(ui.Command("Cancel")) },
+	"Undo":       func() { Execute(ui.Command("Undo")) },
+	"Collect":    func() { Execute(ui.Command("Collect")) },
+	"NavDrawer":  func() { TheUI.ToggleNavDrawer() },
+	"FocusLeft":  func() { TheBaize.gamepadFocus(-1, 0) },
+	"FocusRight": func() { TheBaize.gamepadFocus(1, 0) },
+	"FocusUp":    func() { TheBaize.gamepadFocus(0, -1) },
+	"FocusDown":  func() { TheBaize.gamepadFocus(0, 1) },
+}
+
+// DefaultGamepadButtons is the standard layout button mapping used until the player changes it
+func DefaultGamepadButtons() map[ebiten.StandardGamepadButton]string {
+	return map[ebiten.StandardGamepadButton]string{
+		ebiten.StandardGamepadButtonRightBottom:   "PickUpOrDrop",
+		ebiten.StandardGamepadButtonRightRight:    "PutBack",
+		ebiten.StandardGamepadButtonRightLeft:     "Undo",
+		ebiten.StandardGamepadButtonRightTop:      "Collect",
+		ebiten.StandardGamepadButtonFrontTopLeft:  "NavDrawer",
+		ebiten.StandardGamepadButtonFrontTopRight: "NavDrawer",
+		ebiten.StandardGamepadButtonLeftLeft:      "FocusLeft",
+		ebiten.StandardGamepadButtonLeftRight:     "FocusRight",
+		ebiten.StandardGamepadButtonLeftTop:       "FocusUp",
+		ebiten.StandardGamepadButtonLeftBottom:    "FocusDown",
+	}
+}
+
+// withDefaultGamepadButtons adds the default buttons for any commands missing from buttons,
+// unless the player has given that button to something else
+func withDefaultGamepadButtons(buttons map[ebiten.StandardGamepadButton]string) map[ebiten.StandardGamepadButton]string {
+	if buttons == nil {
+		return DefaultGamepadButtons()
+	}
+	mapped := map[string]bool{}
+	for _, cmd := range buttons {
+		mapped[cmd] = true
+	}
+	for btn, cmd := range DefaultGamepadButtons() {
+		if _, taken := buttons[btn]; !taken && !mapped[cmd] {
+			buttons[btn] = cmd
+		}
+	}
+	return buttons
+}
+
+// ApplyGamepadButtons tells package input which button taps and drags with the gamepad cursor
+func ApplyGamepadButtons() {
+	for btn, cmd := range ThePreferences.GamepadButtons {
+		if cmd == "PickUpOrDrop" {
+			input.GamepadStrokeButton = btn
+			return
+		}
+	}
+}
+
+// gamepadFocus moves the pile focus, and hides the gamepad cursor as the d-pad is being used instead
+func (b *Baize) gamepadFocus(dx, dy int) {
+	input.TheGamepadCursor.Hide()
+	if TheUI.VisibleDrawer() == nil {
+		b.MoveFocus(dx, dy)
+	}
+}
+
+// UpdateGamepads moves the gamepad cursor and executes the commands for any gamepad buttons just pressed
+func (b *Baize) UpdateGamepads() {
+	input.TheGamepadCursor.Update(b.WindowWidth, b.WindowHeight)
+	for _, id := range input.StandardGamepads() {
+		for btn, cmd := range ThePreferences.GamepadButtons {
+			if inpututil.IsStandardGamepadButtonJustPressed(id, btn) {
+				if fn, ok := GamepadCommands[cmd]; ok {
+					fn()
+				}
+			}
+		}
+	}
+}
+
+// drawGamepadCursor draws a cross hair at the gamepad cursor position
+func drawGamepadCursor(screen *ebiten.Image) {
+	x, y := input.TheGamepadCursor.Position()
+	const size, t = 12, 3 // arm length and thickness
+	ebitenutil.DrawRect(screen, float64(x-size), float64(y-1), size*2, t, ExtendedColors["Gold"])
+	ebitenutil.DrawRect(screen, float64(x-1), float64(y-size), t, size*2, ExtendedColors["Gold"])
+}
@@ -0,0 +1,56 @@
+package sol
+
+import (
+	"oddstream.games/gosol/input"
+	"oddstream.games/gosol/ui"
+	"oddstream.games/gosol/util"
+)
+
+const (
+	minZoom = 0.5
+	maxZoom = 3.0
+)
+
+// NotifyGesture is called by the GestureRecognizer when a multi-touch gesture happens
+func (b *Baize) NotifyGesture(v input.GestureEvent) {
+	switch v.Type {
+	case input.GestureBegin:
+		// a second finger means the first finger wasn't dragging or tapping after all
+		if b.stroke != nil {
+			b.stroke.Interrupt()
+			b.stroke = nil
+		}
+		b.pinchStartZoom = b.zoom
+	case input.Pinch:
+		// rescaling the cards is expensive, so wait until the zoom has changed noticeably
+		if z := b.pinchStartZoom * v.Scale; z < b.zoom*0.95 || z > b.zoom*1.05 {
+			b.ZoomAround(z, v.X, v.Y)
+		}
+	case input.PinchEnd:
+		b.ZoomAround(b.pinchStartZoom*v.Scale, v.X, v.Y)
+	case input.TwoFingerTap:
+		Execute(ui.Command("Undo"))
+	case input.ThreeFingerTap:
+		Execute(ui.Command("Hint"))
+	}
+}
+
+// ZoomAround changes the size of the cards, keeping the part of the baize at screen x, y where it is
+func (b *Baize) ZoomAround(zoom float64, x, y int) {
+	zoom = util.Clamp(zoom, minZoom, maxZoom)
+	if zoom == b.zoom {
+		return
+	}
+	// where x, y is on the baize, in slots from the first pile, which stays the same whatever the card size
+	slotX := float64(x-b.dragOffset.X-LeftMargin) / float64(CardWidth+PilePaddingX)
+	slotY := float64(y-b.dragOffset.Y-TopMargin) / float64(CardHeight+PilePaddingY)
+	b.zoom = zoom
+	// scale now, rather than leaving it to Layout, as the margins change with the card size
+	if b.ScaleCards() {
+		b.setFlag(dirtyCardImages)
+	}
+	// dragOffset should only ever be 0 or -ve
+	b.dragOffset.X = util.Min(0, x-LeftMargin-int(slotX*float64(CardWidth+PilePaddingX)))
+	b.dragOffset.Y = util.Min(0, y-TopMargin-int(slotY*float64(CardHeight+PilePaddingY)))
+	b.setFlag(dirtyPileBackgrounds | dirtyPilePositions | dirtyCardPositions)
+}
@@ -1,212 +1,211 @@
-//go:build linux || windows || android
-
 package sol
 
 import (
 	"encoding/json"
 	"log"
-	"os"
-	"path"
-	"runtime"
 	"time"
 
 	"oddstream.games/gosol/util"
 )
 
-func fullPath(jsonFname string) (string, error) {
-	// os.Getenv("HOME") == "" on WASM
-	// could use something like errors.New("math: square root of negative number")
-	userConfigDir, err := os.UserConfigDir()
-	if err != nil {
-		println(err)
-		return "", err
-	}
-	// println("UserConfigDir", userConfigDir) // /home/gilbert/.config
-	return path.Join(userConfigDir, "oddstream.games", "gosol", jsonFname), nil
-}
-
-func makeConfigDir() {
-	userConfigDir, err := os.UserConfigDir()
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	dir := path.Join(userConfigDir, "oddstream.games", "gosol")
-	err = os.MkdirAll(dir, 0755) // https://stackoverflow.com/questions/14249467/os-mkdir-and-os-mkdirall-permission-value
-	if err != nil {
-		log.Fatal(err)
-	}
-	// if path is already a directory, MkdirAll does nothing and returns nil
-}
-
-func loadBytesFromFile(jsonFname string, leaveNoTrace bool) ([]byte, int, error) {
-
-	if runtime.GOARCH == "wasm" {
-		log.Fatal("WASM detected")
-	}
+// Everything is loaded from and saved to TheStorage as JSON, under these keys
+const (
+	preferencesKey = "preferences"
+	statisticsKey  = "statistics"
+	savedGamesKey  = "saved"
+)
 
-	path, err := fullPath(jsonFname)
-	if err != nil {
-		return nil, 0, err
+// rotateBackups shuffles the backups of a key along one place, dropping the oldest,
+// and makes a copy of the key as the newest backup
+func rotateBackups(key string, backups int) error {
+	bytes, err := TheStorage.Load(key)
+	if err != nil || bytes == nil {
+		return err // nothing to back up
 	}
-
-	file, err := os.Open(path)
-	if err == nil && file != nil {
-		var bytes []byte
-		var count int
-		fi, err := file.Stat()
+	for i := backups - 1; i > 0; i-- {
+		older, err := TheStorage.Load(backupName(key, i))
 		if err != nil {
-			log.Fatal(err, " getting FileInfo ", path)
+			return err
 		}
-		if fi.Size() == 0 {
-			log.Print("empty file ", path)
-		} else {
-			bytes = make([]byte, fi.Size()+8)
-			count, err = file.Read(bytes)
-			if err != nil {
-				log.Fatal(err, " reading ", path)
+		if older != nil {
+			if err = TheStorage.Save(backupName(key, i+1), older); err != nil {
+				return err
 			}
 		}
-		err = file.Close()
-		if err != nil {
-			log.Fatal(err, " closing ", path)
-		}
-		println("loaded", path)
-		if leaveNoTrace {
-			os.Remove(path)
-		}
-		return bytes, count, nil
 	}
-	// log.Print(err, path)
-	return nil, 0, nil // file does not exist (which is ok)
+	return TheStorage.Save(backupName(key, 1), bytes)
 }
 
-func saveBytesToFile(bytes []byte, jsonFname string) {
-
-	if runtime.GOARCH == "wasm" {
-		log.Fatal("WASM detected")
-	}
-
-	path, err := fullPath(jsonFname)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	makeConfigDir()
+// savedSharedPreferences is the shared part of the preferences last loaded or saved, or nil if there were none,
+// so Save can tell when a shared preference changes
+var savedSharedPreferences []byte
 
-	file, err := os.Create(path)
+// sharedBytes returns the preferences that are shared with other devices (see deviceLocalPreferences),
+// without when they were changed
+func (prefs *Preferences) sharedBytes() []byte {
+	shared := *prefs
+	shared.Version, shared.SyncURL, shared.DeviceID, shared.Changed = 0, "", "", 0
+	bytes, err := json.Marshal(&shared)
 	if err != nil {
-		log.Fatal(err)
+		log.Println("Preferences.sharedBytes", err)
 	}
-	_, err = file.Write(bytes)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	err = file.Close()
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	println("saved", path)
+	return bytes
 }
 
-// Load an already existing Preferences object from file
+// Load an already existing Preferences object from storage
 func (prefs *Preferences) Load() {
 	if DebugMode {
 		defer util.Duration(time.Now(), "Preferences.Load")
 	}
-	bytes, count, err := loadBytesFromFile("preferences.json", false)
-	if err != nil || count == 0 || bytes == nil {
+	savedSharedPreferences = nil
+	bytes, err := TheStorage.Load(preferencesKey)
+	if err != nil {
+		log.Println("Preferences.Load", err)
+		reportLoadProblem("Preferences could not be read, using defaults")
+		return
+	}
+	if bytes == nil {
 		return
 	}
 
-	// golang gotcha reslice buffer to number of bytes actually read
-	err = json.Unmarshal(bytes[:count], prefs)
-	if err != nil {
-		log.Panic("Preferences.Load Unmarshal", err)
+	// unmarshal into a copy, with fresh maps as the copy would otherwise share them,
+	// so a corrupt file leaves the defaults as they were
+	loaded := *prefs
+	loaded.GamepadButtons, loaded.KeyBindings = nil, nil
+	if err = json.Unmarshal(bytes, &loaded); err != nil {
+		log.Println("Preferences.Load Unmarshal", err)
+		reportLoadProblem("Preferences were damaged, using defaults")
+		return
 	}
+	// commands added since the file was saved get their default buttons and keys
+	loaded.GamepadButtons = withDefaultGamepadButtons(loaded.GamepadButtons)
+	loaded.KeyBindings = withDefaultKeyBindings(loaded.KeyBindings)
+	*prefs = loaded
+	savedSharedPreferences = prefs.sharedBytes()
+	checkSchemaVersion("Preferences", prefs.Version)
 }
 
-// Save writes the Preferences object to file
+// Save writes the Preferences object to storage
 func (prefs *Preferences) Save() {
 	if DebugMode {
 		defer util.Duration(time.Now(), "Preferences.Save")
 	}
+	prefs.Version = SchemaVersion
+	// preferences that have never been saved don't count as changed, so a new device takes the shared ones
+	shared := prefs.sharedBytes()
+	if savedSharedPreferences != nil && string(shared) != string(savedSharedPreferences) {
+		prefs.Changed = time.Now().UnixMilli()
+	}
+	savedSharedPreferences = shared
 	// warning - calling ebiten function ouside RunGame loop will cause fatal panic
 	bytes, err := json.MarshalIndent(prefs, "", "\t")
 	if err != nil {
-		log.Fatal(err)
+		log.Println("Preferences.Save Marshal", err)
+		return
+	}
+	if err = TheStorage.Save(preferencesKey, bytes); err != nil {
+		log.Println("Preferences.Save", err)
 	}
-	saveBytesToFile(bytes, "preferences.json")
 }
 
-// Load statistics for all variants from JSON to an already-created Statistics object
+// loadStatistics reads one copy of the statistics from st, returning nil if it does not exist, and true if it is damaged
+func loadStatistics(st Storage, key string) (*Statistics, bool) {
+	bytes, err := st.Load(key)
+	if err != nil {
+		log.Println(key, err)
+		return nil, true
+	}
+	if bytes == nil {
+		return nil, false
+	}
+	var s Statistics
+	if err = json.Unmarshal(bytes, &s); err != nil {
+		log.Println(key, err)
+		return nil, true
+	}
+	if s.StatsMap == nil {
+		s.StatsMap = make(map[string]*VariantStatistics)
+	}
+	return &s, false
+}
+
+// Load statistics for all variants from JSON to an already-created Statistics object.
+// If the statistics are damaged, the newest backup that can be read is used instead.
 func (s *Statistics) Load() {
 	if DebugMode {
 		defer util.Duration(time.Now(), "Statistics.Load")
 	}
-	bytes, count, err := loadBytesFromFile("statistics.json", false)
-	if err != nil || count == 0 || bytes == nil {
+	loaded, damaged := loadStatistics(TheStorage, statisticsKey)
+	if !damaged {
+		if loaded != nil {
+			*s = *loaded
+			checkSchemaVersion("Statistics", s.Version)
+		}
 		return
 	}
-
-	// golang gotcha reslice buffer to number of bytes actually read
-	err = json.Unmarshal(bytes[:count], s)
-	if err != nil {
-		log.Fatal(err)
+	for i := 1; i <= statisticsBackups; i++ {
+		if loaded, _ = loadStatistics(TheStorage, backupName(statisticsKey, i)); loaded != nil {
+			*s = *loaded
+			checkSchemaVersion("Statistics", s.Version)
+			reportLoadProblem("Statistics were damaged, recovered from a backup")
+			return
+		}
 	}
+	reportLoadProblem("Statistics were damaged, starting afresh")
 }
 
-// Save writes the Statistics object to file
+// Save writes the Statistics object to storage, keeping the previous copies as backups
 func (s *Statistics) Save() {
 	if DebugMode {
 		defer util.Duration(time.Now(), "Statistics.Save")
 	}
+	s.Version = SchemaVersion
 	bytes, err := json.MarshalIndent(s, "", "\t")
 	if err != nil {
-		log.Fatal(err)
+		log.Println("Statistics.Save Marshal", err)
+		return
 	}
-
-	saveBytesToFile(bytes, "statistics.json")
-}
-
-// Save the entire undo stack to file
-func (b *Baize) Save() {
-	if DebugMode {
-		defer util.Duration(time.Now(), "Baize.Save")
+	if _, damaged := loadStatistics(TheStorage, statisticsKey); !damaged {
+		// don't push a good backup out with a damaged copy
+		if err = rotateBackups(statisticsKey, statisticsBackups); err != nil {
+			log.Println("Statistics.Save backup", err)
+		}
 	}
-	// do not bother to save virgin or completed games
-	// if len(b.undoStack) < 2 || b.Complete() {
-	// 	return
-	// }
-
-	bytes, err := json.MarshalIndent(b.undoStack, "", "\t")
-	if err != nil {
-		log.Fatal(err)
+	if err = TheStorage.Save(statisticsKey, bytes); err != nil {
+		log.Println("Statistics.Save", err)
 	}
+}
 
-	saveBytesToFile(bytes, "saved.json")
+// marshalSavedGames encodes the saved games; they can be large, so they are not indented
+func marshalSavedGames(sg *SavableGames) ([]byte, error) {
+	return json.Marshal(sg)
 }
 
-func LoadUndoStack() []*SavableBaize {
+// writeSavedGames writes the saved games to st; it may be called from a goroutine other than the game loop
+func writeSavedGames(st Storage, bytes []byte) error {
+	return st.Save(savedGamesKey, bytes)
+}
+
+// LoadSavedGames reads the game for each variant from storage
+func LoadSavedGames() *SavableGames {
 	if DebugMode {
-		defer util.Duration(time.Now(), "LoadUndoStack")
+		defer util.Duration(time.Now(), "LoadSavedGames")
 	}
-	bytes, count, err := loadBytesFromFile("saved.json", true)
-	if err != nil || count == 0 || bytes == nil {
+	bytes, err := TheStorage.Load(savedGamesKey)
+	if err != nil {
+		log.Println("LoadSavedGames", err)
+		reportLoadProblem("Saved games could not be read")
 		return nil
 	}
-
-	var undoStack []*SavableBaize
-	// golang gotcha reslice buffer to number of bytes actually read
-	err = json.Unmarshal(bytes[:count], &undoStack)
-	if err != nil {
-		log.Fatal(err)
+	if bytes == nil {
+		return nil
 	}
 
-	if len(undoStack) > 0 {
-		return undoStack
+	savedGames, err := ParseSavedGames(bytes)
+	if err != nil {
+		log.Println("LoadSavedGames", err)
+		reportLoadProblem("Saved games were damaged, starting afresh")
+		return nil
 	}
-	return nil
+	return savedGames
 }
@@ -0,0 +1,199 @@
+package sol
+
+//lint:file-ignore ST1005 Error messages are toasted, so need to be capitalized
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"oddstream.games/gosol/sound"
+	"oddstream.games/gosol/util"
+)
+
+// Keyboard play; the arrow keys move a focus highlight between piles (and between the cards in a pile),
+// Enter or Space picks up the focused tail, and a second Enter or Space drops it on the focused pile.
+// Number keys focus on a tableau and then pick up or drop, as in classic Freecell.
+
+// NavigationTable maps keys to keyboard play functions;
+// unlike CommandTable, these don't close drawers or hide the FAB
+var NavigationTable = map[ebiten.Key]func(){
+	ebiten.KeyArrowLeft:  func() { TheBaize.MoveFocus(-1, 0) },
+	ebiten.KeyArrowRight: func() { TheBaize.MoveFocus(1, 0) },
+	ebiten.KeyArrowUp:    func() { TheBaize.MoveFocus(0, -1) },
+	ebiten.KeyArrowDown:  func() { TheBaize.MoveFocus(0, 1) },
+	ebiten.KeyEnter:      func() { TheBaize.PickUpOrDrop() },
+	ebiten.KeySpace:      func() { TheBaize.PickUpOrDrop() },
+	ebiten.Key1:          func() { TheBaize.FocusTableau(0) },
+	ebiten.Key2:          func() { TheBaize.FocusTableau(1) },
+	ebiten.Key3:          func() { TheBaize.FocusTableau(2) },
+	ebiten.Key4:          func() { TheBaize.FocusTableau(3) },
+	ebiten.Key5:          func() { TheBaize.FocusTableau(4) },
+	ebiten.Key6:          func() { TheBaize.FocusTableau(5) },
+	ebiten.Key7:          func() { TheBaize.FocusTableau(6) },
+	ebiten.Key8:          func() { TheBaize.FocusTableau(7) },
+	ebiten.Key9:          func() { TheBaize.FocusTableau(8) },
+	ebiten.Key0:          func() { TheBaize.FocusTableau(9) },
+}
+
+// focusCard returns the focused card, or nil if the focused pile is empty
+func (b *Baize) focusCard() *Card {
+	if b.focus == nil || b.focus.Empty() {
+		return nil
+	}
+	b.focusIndex = util.ClampInt(b.focusIndex, 0, b.focus.Len()-1)
+	return b.focus.Get(b.focusIndex)
+}
+
+// setFocus focuses on the top card of a pile
+func (b *Baize) setFocus(p *Pile) {
+	b.focus = p
+	b.focusIndex = p.Len() - 1
+}
+
+// ClearFocus removes the focus highlight; called when the mouse or touch is used
+func (b *Baize) ClearFocus() {
+	if b.focus != nil && b.tail != nil {
+		b.CancelTailDrag()
+	}
+	b.focus = nil
+}
+
+// MoveFocus moves the focus to the nearest pile in the direction dx, dy;
+// up and down first move between the face up cards in a fanned pile
+func (b *Baize) MoveFocus(dx, dy int) {
+	if b.focus == nil {
+		// the first arrow key press just shows where the focus is
+		for _, p := range b.piles {
+			if !p.Hidden() {
+				b.setFocus(p)
+				return
+			}
+		}
+		return
+	}
+	if dy != 0 && b.tail == nil && b.focus.FanType() != FAN_NONE {
+		b.focusCard() // clamp the index
+		i := b.focusIndex + dy
+		if i >= 0 && i < b.focus.Len() && !b.focus.Get(i).Prone() {
+			b.focusIndex = i
+			return
+		}
+	}
+	if p := b.nearestPile(dx, dy); p != nil {
+		b.setFocus(p)
+	} else {
+		sound.Play("Blip")
+	}
+}
+
+// nearestPile finds the closest visible pile to the focused pile, in the direction dx, dy
+func (b *Baize) nearestPile(dx, dy int) *Pile {
+	var nearest *Pile
+	var nearestDist int
+	from := b.focus.BaizePos()
+	for _, p := range b.piles {
+		if p == b.focus || p.Hidden() {
+			continue
+		}
+		d := p.BaizePos().Sub(from)
+		var along, across int // distance in the direction of travel, and across it
+		if dx != 0 {
+			along, across = d.X*dx, util.Abs(d.Y)
+		} else {
+			along, across = d.Y*dy, util.Abs(d.X)
+		}
+		if along <= 0 {
+			continue
+		}
+		// prefer piles in the same row (or column)
+		dist := along + across*4
+		if nearest == nil || dist < nearestDist {
+			nearest, nearestDist = p, dist
+		}
+	}
+	return nearest
+}
+
+// FocusTableau focuses on a tableau (by index), and then picks up from it or drops onto it
+func (b *Baize) FocusTableau(n int) {
+	tabs := b.script.Tableaux()
+	if n >= len(tabs) || tabs[n].Hidden() {
+		sound.Play("Blip")
+		return
+	}
+	b.setFocus(tabs[n])
+	b.PickUpOrDrop()
+}
+
+// PickUpOrDrop picks up the focused tail, or drops the tail being held on the focused pile.
+// Picking up from the stock, or dropping back onto the same pile, is the same as a tap.
+func (b *Baize) PickUpOrDrop() {
+	if b.focus == nil {
+		b.MoveFocus(0, 0)
+		return
+	}
+	if b.BotsTurn() {
+		return
+	}
+	if b.tail != nil {
+		src := b.tail[0].Owner()
+		if src == b.focus {
+			b.tapHeld()
+		} else {
+			b.DropTail(b.tail[0], b.focus)
+		}
+		b.setFocus(b.focus)
+		return
+	}
+	c := b.focusCard()
+	if c == nil {
+		crc := b.CRC()
+		b.script.PileTapped(b.focus)
+		if crc != b.CRC() {
+			sound.Play("Slide")
+			b.AfterUserMove()
+		}
+		return
+	}
+	if !b.MakeTail(c) {
+		return
+	}
+	if b.focus.IsStock() {
+		b.tapHeld()
+		return
+	}
+	if ok, err := b.focus.CanMoveTail(b.tail); !ok {
+		sound.Play("Blip")
+		TheUI.Toast(err.Error())
+		b.tail = nil
+		return
+	}
+	// lift the tail a little, so it's obvious it's been picked up
+	b.ApplyToTail((*Card).StartDrag)
+	b.DragTailBy(0, -CardHeight/10)
+	b.FindDropTargets()
+}
+
+// tapHeld treats the held tail as if it had been tapped
+func (b *Baize) tapHeld() {
+	crc := b.CRC()
+	b.script.TailTapped(b.tail)
+	if crc != b.CRC() {
+		sound.Play("Slide")
+		b.AfterUserMove()
+		b.StopTailDrag()
+	} else {
+		b.CancelTailDrag()
+	}
+}
+
+// drawFocus outlines the focused card or pile
+func (b *Baize) drawFocus(screen *ebiten.Image) {
+	var r image.Rectangle
+	if c := b.focusCard(); c != nil {
+		r = c.ScreenRect()
+	} else {
+		r = b.focus.ScreenRect()
+	}
+	drawOutline(screen, r, ExtendedColors["Gold"])
+}
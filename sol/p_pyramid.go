@@ -0,0 +1,96 @@
+package sol
+
+//lint:file-ignore ST1005 Error messages are toasted, so need to be capitalized
+//lint:file-ignore ST1006 Receiver name will be anything I like, thank you
+
+import (
+	"errors"
+	"image"
+)
+
+// Pyramid is a single card pile that may overlap, and be blocked by, other Pyramid piles
+// (used by Pyramid, TriPeaks and friends)
+type Pyramid struct {
+	parent *Pile
+}
+
+// NewPyramid creates a Pyramid pile; x, y are slot coordinates, which may be
+// fractional (eg 2.5) so that the piles can overlap each other
+func NewPyramid(x, y float64) *Pile {
+	slot := image.Point{X: int(x), Y: int(y)}
+	pyramid := NewPile("Pyramid", slot, FAN_NONE, MOVE_ONE)
+	pyramid.slotShift = image.Point{
+		X: int((x - float64(slot.X)) * 100),
+		Y: int((y - float64(slot.Y)) * 100),
+	}
+	pyramid.vtable = &Pyramid{parent: &pyramid}
+	TheBaize.AddPile(&pyramid)
+	return &pyramid
+}
+
+// FindCoveringPiles tells each Pyramid pile which other Pyramid piles overlap it.
+// Piles added later are drawn later, so they are on top.
+// Must be called after the piles have been built (and mirrored).
+func (b *Baize) FindCoveringPiles() {
+	for i, p1 := range b.piles {
+		p1.coveredBy = nil
+		if p1.category != "Pyramid" {
+			continue
+		}
+		pos1 := p1.slot.Mul(100).Add(p1.slotShift)
+		for _, p2 := range b.piles[i+1:] {
+			if p2.category != "Pyramid" {
+				continue
+			}
+			// treat each slot as 100 x 100, so a pile shifted by half a slot overlaps
+			pos2 := p2.slot.Mul(100).Add(p2.slotShift)
+			if pos2.X-pos1.X < 100 && pos1.X-pos2.X < 100 && pos2.Y-pos1.Y < 100 && pos1.Y-pos2.Y < 100 {
+				p1.coveredBy = append(p1.coveredBy, p2)
+			}
+		}
+	}
+}
+
+func (*Pyramid) CanAcceptCard(card *Card) (bool, error) {
+	return false, errors.New("Cannot move a card to a Pyramid")
+}
+
+func (*Pyramid) CanAcceptTail(tail []*Card) (bool, error) {
+	return false, errors.New("Cannot move a card to a Pyramid")
+}
+
+func (self *Pyramid) TailTapped(tail []*Card) {
+	self.parent.DefaultTailTapped(tail)
+}
+
+func (self *Pyramid) Collect() {
+	if !self.parent.Blocked() {
+		self.parent.DefaultCollect()
+	}
+}
+
+func (self *Pyramid) Conformant() bool {
+	return self.parent.Empty()
+}
+
+func (self *Pyramid) Complete() bool {
+	return self.parent.Empty()
+}
+
+func (*Pyramid) UnsortedPairs() int {
+	return 0
+}
+
+func (self *Pyramid) MovableTails() []*MovableTail {
+	// nb same as Cell.MovableTails; FindHomesForTail will reject a blocked card
+	var tails []*MovableTail = []*MovableTail{}
+	if self.parent.Len() > 0 {
+		var card *Card = self.parent.Peek()
+		var tail []*Card = []*Card{card}
+		var homes []*Pile = TheBaize.FindHomesForTail(tail)
+		for _, home := range homes {
+			tails = append(tails, &MovableTail{dst: home, tail: tail})
+		}
+	}
+	return tails
+}
@@ -19,12 +19,18 @@ func NewReserve(slot image.Point, fanType FanType) *Pile {
 	return &reserve
 }
 
-func (*Reserve) CanAcceptCard(card *Card) (bool, error) {
+func (self *Reserve) CanAcceptCard(card *Card) (bool, error) {
+	if ls := loader(self.parent); ls != nil {
+		return ls.LoadError(self.parent, card)
+	}
 	return false, errors.New("Cannot add a card to a Reserve")
 }
 
-func (*Reserve) CanAcceptTail(tail []*Card) (bool, error) {
-	return false, errors.New("Cannot add a card to a Reserve")
+func (self *Reserve) CanAcceptTail(tail []*Card) (bool, error) {
+	if len(tail) > 1 {
+		return false, errors.New("Can only move a single card to a Reserve")
+	}
+	return self.CanAcceptCard(tail[0])
 }
 
 func (self *Reserve) TailTapped(tail []*Card) {
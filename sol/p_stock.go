@@ -88,6 +88,7 @@ func Shuffle(pile *Pile) {
 	if DebugMode {
 		log.Println("shuffle with seed", seed)
 	}
+	TheBaize.seed = seed
 	rand.Seed(seed)
 	for i := 0; i < 6; i++ {
 		// it doesn't make sense, but testing shows that you need to do this
@@ -106,6 +107,16 @@ func NewStock(slot image.Point, fanType FanType, packs int, suits int, cardFilte
 	return &stock
 }
 
+// NewPlayerStock creates an empty Stock owned by a player in a two-player game;
+// cards are dealt into it from the Stock made by NewStock
+func NewPlayerStock(slot image.Point, player int) *Pile {
+	stock := NewPile("Stock", slot, FAN_NONE, MOVE_ONE)
+	stock.vtable = &Stock{parent: &stock}
+	stock.player = player
+	TheBaize.AddPile(&stock)
+	return &stock
+}
+
 func (*Stock) CanAcceptCard(*Card) (bool, error) {
 	return false, errors.New("Cannot move cards to the Stock")
 }
@@ -20,9 +20,15 @@ func NewWaste(slot image.Point, fanType FanType) *Pile {
 }
 
 func (self *Waste) CanAcceptCard(card *Card) (bool, error) {
+	if ls := loader(self.parent); ls != nil {
+		return ls.LoadError(self.parent, card)
+	}
 	if !card.owner.IsStock() {
 		return false, errors.New("Waste can only accept cards from the Stock")
 	}
+	if card.owner.player != self.parent.player {
+		return false, errors.New("Cannot move a card to the other player's Waste")
+	}
 	return true, nil
 }
 
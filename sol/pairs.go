@@ -0,0 +1,117 @@
+package sol
+
+//lint:file-ignore ST1005 Error messages are toasted, so need to be capitalized
+
+import (
+	"errors"
+
+	"oddstream.games/gosol/sound"
+)
+
+// PairScriptInterface is implemented by variants (eg Pyramid) where cards are not built
+// on other piles, but removed to the Discard in pairs (or singly, eg a King in Pyramid)
+type PairScriptInterface interface {
+	PairError(*Card, *Card) (bool, error)
+	SingleError(*Card) (bool, error)
+}
+
+// ExposedError checks that a card can be removed; that it is face up,
+// the top card of it's pile, and that the pile is not covered by another pile
+func ExposedError(c *Card) (bool, error) {
+	if c.Prone() {
+		return false, errors.New("Cannot remove a face down card")
+	}
+	var pile *Pile = c.Owner()
+	if pile.Peek() != c {
+		return false, errors.New("Can only remove the top card of a pile")
+	}
+	return pile.CanMoveTail([]*Card{c})
+}
+
+// RemovePair moves c1 and c2 (which may be nil) to the first Discard
+func RemovePair(c1, c2 *Card) {
+	var discard *Pile = TheBaize.script.Discards()[0]
+	MoveCard(c1.Owner(), discard)
+	if c2 != nil {
+		MoveCard(c2.Owner(), discard)
+	}
+}
+
+// findPairHomes returns the piles that a card could be dropped on to be removed;
+// the Discard if the card can be removed by itself, or piles with an exposed card that it pairs with
+func (b *Baize) findPairHomes(ps PairScriptInterface, card *Card) []*Pile {
+	var homes []*Pile
+	if ok, _ := ps.SingleError(card); ok {
+		return append(homes, b.script.Discards()...)
+	}
+	for _, p := range b.piles {
+		if p == card.Owner() || p.Empty() {
+			continue
+		}
+		if ok, _ := ExposedError(p.Peek()); !ok {
+			continue
+		}
+		if ok, _ := ps.PairError(card, p.Peek()); ok {
+			homes = append(homes, p)
+		}
+	}
+	return homes
+}
+
+// DropPair handles a card being dragged onto the Discard, or onto another card to make a pair
+func (b *Baize) DropPair(ps PairScriptInterface, c *Card, dst *Pile) {
+	var ok bool
+	var err error
+	var c2 *Card
+	switch {
+	case dst.category == "Discard":
+		ok, err = ps.SingleError(c)
+	case dst.Empty():
+		ok, err = false, errors.New("Drop the card on another card to make a pair")
+	default:
+		c2 = dst.Peek()
+		if ok, err = ExposedError(c2); ok {
+			ok, err = ps.PairError(c, c2)
+		}
+	}
+	if !ok {
+		sound.Play("Blip")
+		TheUI.Toast(err.Error())
+		b.CancelTailDrag()
+		return
+	}
+	crc := b.CRC()
+	RemovePair(c, c2)
+	if crc != b.CRC() {
+		b.AfterUserMove()
+	}
+	b.StopTailDrag()
+}
+
+// TapPair handles a tap on a card; a card that can be removed by itself is removed,
+// otherwise the first tap selects the card and a second tap on a matching card removes the pair
+func (b *Baize) TapPair(ps PairScriptInterface, c *Card) {
+	if ok, err := ExposedError(c); !ok {
+		sound.Play("Blip")
+		TheUI.Toast(err.Error())
+		return
+	}
+	if ok, _ := ps.SingleError(c); ok {
+		RemovePair(c, nil)
+		return
+	}
+	switch b.pairCard {
+	case nil:
+		b.pairCard = c
+	case c:
+		b.pairCard = nil
+	default:
+		if ok, err := ps.PairError(b.pairCard, c); !ok {
+			sound.Play("Blip")
+			TheUI.Toast(err.Error())
+			b.pairCard = c
+		} else {
+			RemovePair(b.pairCard, c)
+		}
+	}
+}
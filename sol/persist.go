@@ -0,0 +1,46 @@
+package sol
+
+import (
+	"fmt"
+	"log"
+)
+
+// SchemaVersion is written into every file we save (preferences, statistics, saved games),
+// so that a later version can tell what it is reading; bump it when the layout of one of them changes
+const SchemaVersion = 1
+
+// statisticsBackups is how many older copies of the statistics are kept, newest first
+const statisticsBackups = 3
+
+// loadProblems holds messages about files that could not be read, until there is a UI to toast them
+var loadProblems []string
+
+// reportLoadProblem tells the player that something could not be read, and what was done about it
+func reportLoadProblem(msg string) {
+	log.Println(msg)
+	if TheUI == nil {
+		loadProblems = append(loadProblems, msg)
+	} else {
+		TheUI.Toast(msg)
+	}
+}
+
+// toastLoadProblems shows the problems reported before the UI was created
+func toastLoadProblems() {
+	for _, msg := range loadProblems {
+		TheUI.Toast(msg)
+	}
+	loadProblems = nil
+}
+
+// checkSchemaVersion warns if what was loaded came from a newer version of the game
+func checkSchemaVersion(what string, version int) {
+	if version > SchemaVersion {
+		reportLoadProblem(fmt.Sprintf("%s were saved by a newer version, some may be lost", what))
+	}
+}
+
+// backupName is the name of the i'th backup of a file (1 is the newest)
+func backupName(name string, i int) string {
+	return fmt.Sprintf("%s.%d", name, i)
+}
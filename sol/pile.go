@@ -7,7 +7,6 @@ import (
 	"errors"
 	"fmt"
 	"image"
-	"image/color"
 	"log"
 
 	"github.com/fogleman/gg"
@@ -85,6 +84,7 @@ type Pile struct {
 	vtable    PileVtable
 	category  string
 	slot      image.Point
+	slotShift image.Point // fraction of a slot (in percent) to shift by, for overlapping piles
 	fanType   FanType
 	moveType  MoveType
 	cards     []*Card
@@ -93,10 +93,12 @@ type Pile struct {
 	pos2      image.Point // waste pos #1
 	fanFactor float64
 	// buddyPos    image.Point
-	label  string
-	symbol rune
-	img    *ebiten.Image
-	target bool // experimental, might delete later, IDK
+	label     string
+	symbol    rune
+	img       *ebiten.Image
+	target    bool    // experimental, might delete later, IDK
+	coveredBy []*Pile // piles that overlap this one, see Baize.FindCoveringPiles
+	player    int     // player (1 or 2) that owns this pile in a two-player game, 0 if shared
 }
 
 func NewPile(category string, slot image.Point, fanType FanType, moveType MoveType) Pile {
@@ -131,6 +133,16 @@ func (self *Pile) IsStock() bool {
 	return self.category == "Stock"
 }
 
+// Blocked returns true if this pile is overlapped by another pile that still contains cards
+func (self *Pile) Blocked() bool {
+	for _, p := range self.coveredBy {
+		if !p.Empty() {
+			return true
+		}
+	}
+	return false
+}
+
 // Deprecated: not needed in new model
 func (self *Pile) Cards() []*Card { // TODO RETIRE
 	return self.cards
@@ -278,6 +290,59 @@ func (self *Pile) SetSlot(slot image.Point) {
 	self.slot = slot
 }
 
+// SlotShift returns the fraction of a slot (in percent) this pile is shifted by
+func (self *Pile) SlotShift() image.Point {
+	return self.slotShift
+}
+
+func (self *Pile) SetSlotShift(shift image.Point) {
+	self.slotShift = shift
+}
+
+// Player returns the player (1 or 2) that owns this pile in a two-player game, or 0 if it is shared
+func (self *Pile) Player() int {
+	return self.player
+}
+
+func (self *Pile) SetPlayer(player int) {
+	self.player = player
+}
+
+// Neighbour returns the pile in the next slot in the direction dx, dy, or nil if there isn't one.
+// Directions are as the script laid the piles out, so left and right are swapped if the baize is mirrored.
+func (self *Pile) Neighbour(dx, dy int) *Pile {
+	if ThePreferences.MirrorBaize {
+		dx = -dx
+	}
+	var slot image.Point = self.slot.Add(image.Point{X: dx, Y: dy})
+	for _, p := range TheBaize.piles {
+		if p.slot.Eq(slot) && p.slotShift.Eq(self.slotShift) {
+			return p
+		}
+	}
+	return nil
+}
+
+// Left returns the pile to the left of this one, or nil
+func (self *Pile) Left() *Pile {
+	return self.Neighbour(-1, 0)
+}
+
+// Right returns the pile to the right of this one, or nil
+func (self *Pile) Right() *Pile {
+	return self.Neighbour(1, 0)
+}
+
+// Above returns the pile above this one, or nil
+func (self *Pile) Above() *Pile {
+	return self.Neighbour(0, -1)
+}
+
+// Below returns the pile below this one, or nil
+func (self *Pile) Below() *Pile {
+	return self.Neighbour(0, 1)
+}
+
 // SetBaizePos sets the position of this Pile in Baize coords,
 // and also sets the auxillary waste pile fanned positions
 func (self *Pile) SetBaizePos(pos image.Point) {
@@ -469,6 +534,12 @@ func (self *Pile) IndexOf(card *Card) int {
 }
 
 func (self *Pile) CanMoveTail(tail []*Card) (bool, error) {
+	if self.Blocked() {
+		return false, errors.New("That card is covered by another card")
+	}
+	if self.player != 0 && self.player != TheBaize.Turn() {
+		return false, errors.New("Cannot move the other player's cards")
+	}
 	if !self.IsStock() {
 		if AnyCardsProne(tail) {
 			return false, errors.New("Cannot move a face down card")
@@ -558,26 +629,23 @@ func (self *Pile) DefaultTailTapped(tail []*Card) {
 	// 		MoveTail(card, homes[0])
 	// 	}
 	card := tail[0]
+	if TheBaize.ContinueTapCycle(card) {
+		return
+	}
 	if len(card.destinations) > 0 {
-		var dst *Pile
-		if len(card.destinations) == 1 {
-			dst = card.destinations[0]
-		} else {
-			dst = TheBaize.BestDestination(card, card.destinations)
-		}
+		dsts := TheBaize.RankDestinations(card, card.destinations)
 		src := card.owner
-		tail = src.MakeTail(card)
-		if len(tail) == 1 {
-			MoveCard(src, dst)
-		} else {
-			MoveTail(card, dst)
-		}
+		moveTailTo(card, dsts[0])
+		TheBaize.StartTapCycle(card, src, dsts)
 	} else {
 		sound.Play("Blip")
 	}
 }
 
 func (self *Pile) DefaultCollect() {
+	if self.player != 0 && self.player != TheBaize.Turn() {
+		return
+	}
 	for _, fp := range TheBaize.script.Foundations() {
 		for {
 			// loop to get as many cards as possible from this pile
@@ -646,15 +714,19 @@ func (self *Pile) CreateBackgroundImage() {
 		// off-screen? don't bother
 		return
 	}
-	if self.category == "Reserve" {
-		// don't draw anything for reserve piles
+	if self.category == "Reserve" || self.category == "Pyramid" {
+		// don't draw anything for reserve piles, or piles that overlap each other
 		return
 	}
 	dc := gg.NewContext(CardWidth, CardHeight)
-	dc.SetColor(color.NRGBA{255, 255, 255, 31})
-	dc.SetLineWidth(2)
+	dc.SetColor(pileOutlineColor())
+	lineWidth := 2.0
+	if ThePreferences.HighContrast {
+		lineWidth = 3.0
+	}
+	dc.SetLineWidth(lineWidth)
 	// draw the RoundedRect entirely INSIDE the context
-	dc.DrawRoundedRectangle(1, 1, float64(CardWidth-2), float64(CardHeight-2), CardCornerRadius)
+	dc.DrawRoundedRectangle(lineWidth/2, lineWidth/2, float64(CardWidth)-lineWidth, float64(CardHeight)-lineWidth, CardCornerRadius)
 	switch self.category {
 	case "Discard":
 		dc.Fill()
@@ -1,13 +1,18 @@
 package sol
 
+import "github.com/hajimehoshi/ebiten/v2"
+
 // Preferences contains the settings and preferences for the user
 type Preferences struct {
 	// Capitals to emit to json
+	Version                         int // see SchemaVersion
 	Title                           string
 	Variant                         string
 	BaizeColor                      string
+	Palette                         string // name of the preset colors last chosen, see Palettes
 	CardFaceColor                   string
 	CardBackColor                   string
+	CardTheme                       string `json:",omitempty"` // name of the image set used for card faces and backs, "" to draw them
 	BlackColor                      string
 	RedColor                        string
 	ClubColor                       string
@@ -15,36 +20,57 @@ type Preferences struct {
 	HeartColor                      string
 	SpadeColor                      string
 	FourColors                      bool
+	HighContrast                    bool // hollow red suit symbols, hatched court cards, bold outlines
+	IllustratedCourtCards           bool // draw figures on Jacks, Queens and Kings, rather than just a large suit symbol
 	FixedCards                      bool
 	PowerMoves                      bool
 	Mute                            bool
 	Volume                          float64
 	MirrorBaize                     bool
 	PreferredWindow                 bool
+	BotOpponent                     bool // in two-player games, player 2 is a bot rather than a local human
+	ShowDropTargets                 bool // while dragging, outline the piles that would accept the cards
+	SnapDrops                       bool // dropping cards near a pile that would accept them moves them there
+	AutosaveMoves                   int  // save the games every this many moves, 0 to only save on exit or losing focus
 	CardRatio                       float64
 	FixedCardWidth, FixedCardHeight int
+	GamepadButtons                  map[ebiten.StandardGamepadButton]string // standard layout gamepad button to GamepadCommands name
+	KeyBindings                     map[string]string                       // command name to comma separated key bindings, eg "U, Ctrl+Z"
+	SyncURL                         string                                  `json:",omitempty"` // key/value store to share statistics and preferences with other devices
+	DeviceID                        string                                  `json:",omitempty"` // identifies this device to the key/value store
+	Changed                         int64                                   `json:",omitempty"` // when a shared preference was last changed, in Unix milliseconds; the newer copy wins when syncing
 }
 
 // ThePreferences holds serialized game progress data
+var ThePreferences = DefaultPreferences()
+
+// DefaultPreferences returns the preferences a new player starts with
 // Colors are named from the web extended colors at https://en.wikipedia.org/wiki/Web_colors
-var ThePreferences = &Preferences{
-	Title:           "Solitaire",
-	Variant:         "Klondike",
-	BaizeColor:      "BaizeGreen",
-	PowerMoves:      true,
-	CardFaceColor:   "Ivory",
-	CardBackColor:   "CornflowerBlue",
-	FourColors:      false,
-	RedColor:        "Crimson",
-	BlackColor:      "Black",
-	ClubColor:       "DarkGreen",
-	DiamondColor:    "DarkBlue",
-	HeartColor:      "Crimson",
-	SpadeColor:      "Black",
-	FixedCards:      true,
-	Mute:            false,
-	Volume:          1.0,
-	FixedCardWidth:  90,
-	FixedCardHeight: 122,
-	CardRatio:       1.357,
+func DefaultPreferences() *Preferences {
+	return &Preferences{
+		Title:                 "Solitaire",
+		Variant:               "Klondike",
+		BaizeColor:            "BaizeGreen",
+		PowerMoves:            true,
+		CardFaceColor:         "Ivory",
+		CardBackColor:         "CornflowerBlue",
+		FourColors:            false,
+		IllustratedCourtCards: true,
+		RedColor:              "Crimson",
+		BlackColor:            "Black",
+		ClubColor:             "DarkGreen",
+		DiamondColor:          "DarkBlue",
+		HeartColor:            "Crimson",
+		SpadeColor:            "Black",
+		FixedCards:            true,
+		BotOpponent:           true,
+		Mute:                  false,
+		Volume:                1.0,
+		FixedCardWidth:        90,
+		FixedCardHeight:       122,
+		CardRatio:             1.357,
+		AutosaveMoves:         5,
+		GamepadButtons:        DefaultGamepadButtons(),
+		KeyBindings:           DefaultKeyBindings(),
+	}
 }
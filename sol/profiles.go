@@ -0,0 +1,264 @@
+package sol
+
+//lint:file-ignore ST1005 Error messages are toasted, so need to be capitalized
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"oddstream.games/gosol/sound"
+	"oddstream.games/gosol/ui"
+)
+
+// Profiles let several people share a device. Each profile has its own preferences, statistics
+// and saved games, kept in its own corner of the storage. The first profile uses the top level
+// of the storage, so the files from before there were profiles become its own.
+
+// Profile is one player
+type Profile struct {
+	Name string
+	Dir  string `json:",omitempty"` // prefix of the profile's keys in storage, empty for the first profile
+}
+
+// Profiles is the list of profiles, saved under the profilesKey at the top level of the storage
+type Profiles struct {
+	Version  int // see SchemaVersion
+	Current  int // index of the profile being played
+	Profiles []*Profile
+}
+
+const (
+	profilesKey        = "profiles"
+	defaultProfileName = "Player 1"
+	maxProfileName     = 24
+)
+
+// profileKeys are the keys each profile keeps in its storage, so a deleted profile can be tidied away
+var profileKeys = []string{preferencesKey, statisticsKey, savedGamesKey}
+
+// TheProfiles is the list of profiles
+var TheProfiles *Profiles
+
+// rootStorage is the storage holding the list of profiles, and the first profile
+var rootStorage Storage
+
+// loadProfiles reads the list of profiles, and makes TheStorage the current profile's storage
+func loadProfiles() {
+	rootStorage = TheStorage
+	TheProfiles = &Profiles{}
+	bytes, err := rootStorage.Load(profilesKey)
+	if err != nil {
+		log.Println("loadProfiles", err)
+	} else if bytes != nil {
+		if err = json.Unmarshal(bytes, TheProfiles); err != nil {
+			log.Println("loadProfiles", err)
+			reportLoadProblem("Profiles were damaged, using the first one")
+			TheProfiles = &Profiles{}
+		}
+		checkSchemaVersion("Profiles", TheProfiles.Version)
+	}
+	if len(TheProfiles.Profiles) == 0 {
+		TheProfiles.Profiles = []*Profile{{Name: defaultProfileName}}
+	}
+	if TheProfiles.Current < 0 || TheProfiles.Current >= len(TheProfiles.Profiles) {
+		TheProfiles.Current = 0
+	}
+	TheStorage = TheProfiles.storage(TheProfiles.Current)
+}
+
+// save writes the list of profiles
+func (ps *Profiles) save() {
+	ps.Version = SchemaVersion
+	bytes, err := json.MarshalIndent(ps, "", "\t")
+	if err != nil {
+		log.Println("Profiles.save Marshal", err)
+		return
+	}
+	if err = rootStorage.Save(profilesKey, bytes); err != nil {
+		log.Println("Profiles.save", err)
+	}
+}
+
+// storage returns the storage for profile i
+func (ps *Profiles) storage(i int) Storage {
+	if dir := ps.Profiles[i].Dir; dir != "" {
+		return &PrefixStorage{Storage: rootStorage, Prefix: dir + "/"}
+	}
+	return rootStorage
+}
+
+// CurrentName returns the name of the profile being played
+func (ps *Profiles) CurrentName() string {
+	return ps.Profiles[ps.Current].Name
+}
+
+// checkProfileName returns an error if name cannot be used for a profile
+func (ps *Profiles) checkProfileName(name string, except int) error {
+	if name == "" {
+		return fmt.Errorf("A profile needs a name")
+	}
+	if len([]rune(name)) > maxProfileName {
+		return fmt.Errorf("Profile names can be up to %d letters long", maxProfileName)
+	}
+	for _, r := range name {
+		if !unicode.IsPrint(r) {
+			return fmt.Errorf("Profile names can't contain '%q'", r)
+		}
+	}
+	for i, p := range ps.Profiles {
+		if i != except && strings.EqualFold(p.Name, name) {
+			return fmt.Errorf("There is already a profile called %s", name)
+		}
+	}
+	return nil
+}
+
+// nextProfileName returns a name for a new profile, to be changed by the player if they like
+func (ps *Profiles) nextProfileName() string {
+	for n := len(ps.Profiles) + 1; ; n++ {
+		name := "Player " + strconv.Itoa(n)
+		if ps.checkProfileName(name, -1) == nil {
+			return name
+		}
+	}
+}
+
+// CreateProfile adds a profile, and switches to it
+func CreateProfile(name string) {
+	if err := TheProfiles.checkProfileName(name, -1); err != nil {
+		TheUI.Toast(err.Error())
+		sound.Play("Blip")
+		return
+	}
+	TheProfiles.Profiles = append(TheProfiles.Profiles, &Profile{Name: name, Dir: "profile-" + newDeviceID()})
+	TheProfiles.save()
+	SwitchProfile(len(TheProfiles.Profiles) - 1)
+}
+
+// RenameProfile changes the name of profile i
+func RenameProfile(i int, name string) {
+	if i < 0 || i >= len(TheProfiles.Profiles) {
+		return
+	}
+	name = strings.TrimSpace(name)
+	if err := TheProfiles.checkProfileName(name, i); err != nil {
+		TheUI.Toast(err.Error())
+		sound.Play("Blip")
+		return
+	}
+	TheProfiles.Profiles[i].Name = name
+	TheProfiles.save()
+}
+
+// DeleteProfile removes profile i, and everything kept in its storage
+func DeleteProfile(i int) {
+	if i < 0 || i >= len(TheProfiles.Profiles) {
+		return
+	}
+	if i == TheProfiles.Current {
+		TheUI.Toast("Cannot delete the profile being played")
+		sound.Play("Blip")
+		return
+	}
+	st := TheProfiles.storage(i)
+	for _, key := range profileKeys {
+		if err := st.Delete(key); err != nil {
+			log.Println("DeleteProfile", key, err)
+		}
+	}
+	for n := 1; n <= statisticsBackups; n++ {
+		st.Delete(backupName(statisticsKey, n))
+	}
+	name := TheProfiles.Profiles[i].Name
+	TheProfiles.Profiles = append(TheProfiles.Profiles[:i], TheProfiles.Profiles[i+1:]...)
+	if TheProfiles.Current > i {
+		TheProfiles.Current--
+	}
+	TheProfiles.save()
+	TheUI.Toast(fmt.Sprintf("Deleted profile %s", name))
+}
+
+// applyPreferences makes the game follow ThePreferences, after they have been loaded
+func applyPreferences() {
+	ApplyGamepadButtons()
+	applyVolume()
+	if (TheCardTheme == nil && ThePreferences.CardTheme != "") || (TheCardTheme != nil && TheCardTheme.Name != ThePreferences.CardTheme) {
+		if err := useCardTheme(ThePreferences.CardTheme); err != nil {
+			reportLoadProblem(err.Error())
+		}
+		if TheBaize != nil {
+			TheBaize.setFlag(dirtyCardImages)
+		}
+	}
+}
+
+// SwitchProfile saves the current game, then carries on with profile i's preferences, statistics and game
+func SwitchProfile(i int) {
+	if i < 0 || i >= len(TheProfiles.Profiles) || i == TheProfiles.Current {
+		return
+	}
+	if !NoGameSave {
+		TheBaize.Save()
+	}
+	ThePreferences.Save()
+
+	TheProfiles.Current = i
+	TheProfiles.save()
+	TheStorage = TheProfiles.storage(i)
+
+	*ThePreferences = *DefaultPreferences()
+	ThePreferences.Load()
+	startSync()
+	applyPreferences()
+	if err := LoadKeyBindings(); err != nil {
+		TheUI.Toast(err.Error())
+	}
+	TheStatistics = NewStatistics()
+
+	TheBaize.savedGames = nil
+	TheBaize.StartFreshGame()
+	if !NoGameLoad {
+		if savedGames := LoadSavedGames(); savedGames != nil {
+			TheBaize.SetSavedGames(savedGames)
+		}
+	}
+	TheUI.Toast(fmt.Sprintf("Playing as %s", TheProfiles.CurrentName()))
+}
+
+// renamingProfile is the profile whose new name is being typed, or -1
+var renamingProfile = -1
+
+// StartRenamingProfile shows profile i as a text input, to type its new name into
+func StartRenamingProfile(i int) {
+	if i < 0 || i >= len(TheProfiles.Profiles) {
+		return
+	}
+	renamingProfile = i
+	showProfilesDrawer(i)
+}
+
+// FinishRenamingProfile gives the profile being renamed its new name
+func FinishRenamingProfile(name string) {
+	RenameProfile(renamingProfile, name)
+	renamingProfile = -1
+	ShowProfilesDrawer()
+}
+
+// ShowProfilesDrawer lists the profiles
+func ShowProfilesDrawer() {
+	showProfilesDrawer(-1)
+}
+
+// showProfilesDrawer lists the profiles, with profile editing as a text input (or none, if -1)
+func showProfilesDrawer(editing int) {
+	var entries []ui.ProfileEntry
+	for i, p := range TheProfiles.Profiles {
+		entries = append(entries, ui.ProfileEntry{ID: strconv.Itoa(i), Name: p.Name, Current: i == TheProfiles.Current,
+			Editing: i == editing, MaxLength: maxProfileName})
+	}
+	TheUI.ShowProfilesDrawer(entries)
+}
@@ -0,0 +1,130 @@
+package sol
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+)
+
+// Each variant has its own game in progress. Changing variant puts the current game aside in
+// Baize.savedGames, and picks up the new variant's game where it was left; saved.json holds them all.
+
+// SavableGames is what gets saved in saved.json
+type SavableGames struct {
+	Version int // see SchemaVersion
+	Games   []*SavableUndoTree
+}
+
+// CheckSavable returns an error if sb was not saved from a game laid out like this one
+func (b *Baize) CheckSavable(sb *SavableBaize) error {
+	if len(b.piles) != len(sb.Piles) {
+		return fmt.Errorf("saved game has %d piles, expected %d", len(sb.Piles), len(b.piles))
+	}
+	for i, sp := range sb.Piles {
+		if sp == nil || sp.Category != b.piles[i].category {
+			return fmt.Errorf("saved game pile %d is not a %s", i, b.piles[i].category)
+		}
+	}
+	return nil
+}
+
+// checkUndoTree returns an error if tree was not saved from this variant
+func (b *Baize) checkUndoTree(tree *SavableUndoTree) error {
+	if tree.Variant != "" && tree.Variant != ThePreferences.Variant {
+		return fmt.Errorf("saved game is %s, not %s", tree.Variant, ThePreferences.Variant)
+	}
+	for _, n := range tree.Nodes {
+		if err := b.CheckSavable(n.State); err != nil {
+			return err
+		}
+	}
+	for _, bm := range tree.Bookmarks {
+		if err := b.CheckSavable(bm.State); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// putGameAside keeps the current game, unless it is not worth keeping, to be resumed when the variant is played again
+func (b *Baize) putGameAside() {
+	if b.savedGames == nil {
+		b.savedGames = make(map[string]*SavableUndoTree)
+	}
+	// a virgin game has one state on the undo stack
+	if len(b.undoStack) > 1 && !b.Complete() {
+		b.savedGames[ThePreferences.Variant] = b.SavableUndoTree()
+	} else {
+		delete(b.savedGames, ThePreferences.Variant)
+	}
+}
+
+// resumeSavedGame replaces the current game with the saved game for the current variant, if there is one
+func (b *Baize) resumeSavedGame() bool {
+	tree, ok := b.savedGames[ThePreferences.Variant]
+	if !ok {
+		return false
+	}
+	delete(b.savedGames, ThePreferences.Variant)
+	if err := b.SetUndoTree(tree); err != nil {
+		log.Println(err)
+		return false
+	}
+	return true
+}
+
+// SavableGames returns the current game, and the games put aside, for saving
+func (b *Baize) SavableGames() *SavableGames {
+	var others []*SavableUndoTree
+	for variant, tree := range b.savedGames {
+		if variant != ThePreferences.Variant {
+			others = append(others, tree)
+		}
+	}
+	sort.Slice(others, func(i, j int) bool { return others[i].Variant < others[j].Variant })
+	sg := &SavableGames{Version: SchemaVersion}
+	if len(b.undoStack) > 0 {
+		sg.Games = append(sg.Games, b.SavableUndoTree())
+	}
+	sg.Games = append(sg.Games, others...)
+	return sg
+}
+
+// SetSavedGames takes the loaded games, and resumes the one for the current variant
+func (b *Baize) SetSavedGames(sg *SavableGames) {
+	b.savedGames = make(map[string]*SavableUndoTree)
+	for _, tree := range sg.Games {
+		b.savedGames[tree.Variant] = tree
+	}
+	b.resumeSavedGame()
+}
+
+// ParseSavedGames unmarshals saved.json, which may hold (from older versions) a single undo tree or undo stack
+func ParseSavedGames(bytes []byte) (*SavableGames, error) {
+	var sg SavableGames
+	// older versions saved an undo tree or undo stack, neither of which has a Version
+	if err := json.Unmarshal(bytes, &sg); err == nil && (sg.Version > 0 || len(sg.Games) > 0) {
+		checkSchemaVersion("Saved games", sg.Version)
+		for i, tree := range sg.Games {
+			if err := tree.validate(); err != nil {
+				return nil, err
+			}
+			if tree.Variant == "" {
+				return nil, fmt.Errorf("saved game %d has no variant", i)
+			}
+		}
+		return &sg, nil
+	}
+	tree, err := ParseUndoTree(bytes)
+	if err != nil {
+		return nil, err
+	}
+	if tree == nil {
+		return nil, errors.New("no saved game")
+	}
+	// older versions only saved the game for the current variant
+	tree.Variant = ThePreferences.Variant
+	return &SavableGames{Games: []*SavableUndoTree{tree}}, nil
+}
@@ -5,7 +5,9 @@ package sol
 import (
 	"errors"
 	"fmt"
+	"math/rand"
 	"sort"
+	"strings"
 
 	"oddstream.games/gosol/util"
 )
@@ -80,6 +82,21 @@ type ScriptInterface interface {
 	Waste() *Pile
 }
 
+// WeightedScriptInterface is implemented by scripts that have their own idea of where a tapped card should go;
+// the destination with the highest weight is tried first (see DefaultDestinationWeight)
+type WeightedScriptInterface interface {
+	DestinationWeight(card *Card, dst *Pile) int
+}
+
+// PositionalScriptInterface is implemented by variants (eg Montana) where a card is
+// in the right place because of it's neighbours, rather than the pile it's in,
+// or (eg Pyramid) that are won by clearing some piles while others still hold cards,
+// so the piles cannot judge by themselves how complete the game is
+type PositionalScriptInterface interface {
+	Complete() bool
+	PercentComplete() int
+}
+
 var Variants = map[string]ScriptInterface{
 	"Agnes Bernauer":      &Agnes{},
 	"American Toad":       &Toad{},
@@ -88,7 +105,12 @@ var Variants = map[string]ScriptInterface{
 	"Canfield":            &Canfield{draw: 3, recycles: 32767, tabCompareFunc: CardPair.Compare_DownAltColorWrap},
 	"Storehouse":          &Canfield{draw: 1, recycles: 2, tabCompareFunc: CardPair.Compare_DownSuitWrap, variant: "storehouse"},
 	"Duchess":             &Duchess{},
+	"La Belle Lucie":      &LaBelleLucie{},
+	"Trefoil":             &LaBelleLucie{trefoil: true},
 	"Klondike":            &Klondike{draw: 1, recycles: 2},
+	"Montana":             &Montana{variant: "montana"},
+	"Blue Moon":           &Montana{variant: "blue moon"},
+	"Red Moon":            &Montana{variant: "red moon"},
 	"Klondike Draw Three": &Klondike{draw: 3, recycles: 9},
 	"Thoughtful":          &Klondike{draw: 1, recycles: 32767, thoughtful: true},
 	"Easy":                &Easy{},
@@ -174,7 +196,10 @@ var Variants = map[string]ScriptInterface{
 		tabs:        []int{3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14},
 		cardsPerTab: 5,
 	},
+	"Golf":              &Golf{},
 	"Penguin":           &Penguin{},
+	"Pyramid":           &PyramidSolitaire{},
+	"TriPeaks":          &TriPeaks{},
 	"Scorpion":          &Scorpion{},
 	"Simple Simon":      &SimpleSimon{},
 	"Spider One Suit":   &Spider{packs: 8, suits: 1},
@@ -183,6 +208,8 @@ var Variants = map[string]ScriptInterface{
 	"Whitehead":         &Whitehead{},
 	"Yukon":             &Yukon{},
 	"Yukon Cells":       &Yukon{extraCells: 2},
+	"Russian Bank":      &RussianBank{},
+	"Spite and Malice":  &SpiteAndMalice{},
 	"Crimean":           &Crimean{},
 	"Ukranian":          &Crimean{ukranian: true},
 }
@@ -191,14 +218,18 @@ var VariantGroups = map[string][]string{
 	// "All" added dynamically by func init()
 	// don't have Agnes here (as a group) because it would come before All
 	// and Agnes Sorel is retired because it's just too hard
-	"> Klondike":      {"Klondike", "Klondike Draw Three", "Thoughtful", "Whitehead"},
-	"> Forty Thieves": {"Forty Thieves", "Number Ten", "Red and Black", "Indian", "Rank and File", "Sixty Thieves", "Josephine", "Limited", "Forty and Eight", "Lucas", "Busy Aces", "Maria", "Streets"},
-	"> Spider":        {"Spider One Suit", "Spider Two Suits", "Spider Four Suits", "Scorpion"},
-	"> Canfield":      {"Canfield", "Storehouse", "Duchess", "American Toad"},
-	"> Freecell":      {"Freecell", "Eight Off"},
-	"> Yukon":         {"Yukon", "Yukon Cells", "Alaska"},
-	"> Puzzlers":      {"Penguin", "Simple Simon", "Baker's Dozen", "Freecell"},
-	"> Places":        {"Australian", "Yukon", "Klondike", "Crimean", "Ukranian"},
+	"> Klondike":       {"Klondike", "Klondike Draw Three", "Thoughtful", "Whitehead"},
+	"> Forty Thieves":  {"Forty Thieves", "Number Ten", "Red and Black", "Indian", "Rank and File", "Sixty Thieves", "Josephine", "Limited", "Forty and Eight", "Lucas", "Busy Aces", "Maria", "Streets"},
+	"> Spider":         {"Spider One Suit", "Spider Two Suits", "Spider Four Suits", "Scorpion"},
+	"> Canfield":       {"Canfield", "Storehouse", "Duchess", "American Toad"},
+	"> Freecell":       {"Freecell", "Eight Off"},
+	"> Yukon":          {"Yukon", "Yukon Cells", "Alaska"},
+	"> Puzzlers":       {"Penguin", "Simple Simon", "Baker's Dozen", "Freecell"},
+	"> Places":         {"Australian", "Yukon", "Klondike", "Crimean", "Ukranian"},
+	"> Pyramid":        {"Pyramid", "TriPeaks", "Golf"},
+	"> La Belle Lucie": {"La Belle Lucie", "Trefoil"},
+	"> Montana":        {"Montana", "Blue Moon", "Red Moon"},
+	"> Two Player":     {"Russian Bank", "Spite and Malice"},
 }
 
 func init() {
@@ -227,8 +258,39 @@ func VariantNames(group string) []string {
 	return vnames
 }
 
+// FindVariantNames returns the variants whose names contain query, ignoring case
+func FindVariantNames(query string) []string {
+	query = strings.ToLower(strings.TrimSpace(query))
+	var vnames []string
+	for _, v := range VariantGroups["> All"] {
+		if strings.Contains(strings.ToLower(v), query) {
+			vnames = append(vnames, v)
+		}
+	}
+	return vnames
+}
+
 // useful generic game library of functions
 
+// pilesCleared returns true if all the piles are empty
+func pilesCleared(piles []*Pile) bool {
+	for _, p := range piles {
+		if !p.Empty() {
+			return false
+		}
+	}
+	return true
+}
+
+// percentCleared returns how many of the dealt cards have been cleared from the piles, as a percentage
+func percentCleared(piles []*Pile, dealt int) int {
+	var left int
+	for _, p := range piles {
+		left += p.Len()
+	}
+	return (dealt - left) * 100 / dealt
+}
+
 func Compare_Empty(p *Pile, c *Card) (bool, error) {
 
 	if p.Label() != "" {
@@ -249,19 +311,49 @@ func RecycleWasteToStock(waste *Pile, stock *Pile) {
 			MoveCard(waste, stock)
 		}
 		TheBaize.SetRecycles(TheBaize.Recycles() - 1)
-		switch {
-		case TheBaize.recycles == 0:
-			TheUI.Toast("No more recycles")
-		case TheBaize.recycles == 1:
-			TheUI.Toast(fmt.Sprintf("%d recycle remaining", TheBaize.Recycles()))
-		case TheBaize.recycles < 10:
-			TheUI.Toast(fmt.Sprintf("%d recycles remaining", TheBaize.Recycles()))
-		}
+		toastRecycles("recycle")
 	} else {
 		TheUI.Toast("No more recycles")
 	}
 }
 
+// Redeal gathers the cards from the gather piles into the stock, shuffles them, and deals them
+// into the deal piles, cardsPerPile at a time, so some piles at the end may be left empty.
+// Uses up one of the Baize recycles, which count the redeals remaining.
+func Redeal(stock *Pile, gather []*Pile, deal []*Pile, cardsPerPile int) {
+	if TheBaize.Recycles() == 0 {
+		TheUI.Toast("No more redeals")
+		return
+	}
+	for _, pile := range gather {
+		for pile.Len() > 0 {
+			MoveCard(pile, stock)
+		}
+	}
+	// use the deal's seed, offset by the redeal number, so that replaying a redeal
+	// (after an undo) produces the same result
+	rng := rand.New(rand.NewSource(TheBaize.seed + int64(TheBaize.Recycles())))
+	rng.Shuffle(stock.Len(), stock.Swap)
+	for _, pile := range deal {
+		for i := 0; i < cardsPerPile && stock.Len() > 0; i++ {
+			MoveCard(stock, pile)
+		}
+	}
+	TheBaize.SetRecycles(TheBaize.Recycles() - 1)
+	toastRecycles("redeal")
+}
+
+func toastRecycles(what string) {
+	switch {
+	case TheBaize.recycles == 0:
+		TheUI.Toast(fmt.Sprintf("No more %ss", what))
+	case TheBaize.recycles == 1:
+		TheUI.Toast(fmt.Sprintf("%d %s remaining", TheBaize.Recycles(), what))
+	case TheBaize.recycles < 10:
+		TheUI.Toast(fmt.Sprintf("%d %ss remaining", TheBaize.Recycles(), what))
+	}
+}
+
 func UnsortedPairs(pile *Pile, fn func(CardPair) (bool, error)) int {
 	if pile.Len() < 2 {
 		return 0
@@ -1,14 +1,204 @@
 package sol
 
+import (
+	"log"
+	"strconv"
+
+	"oddstream.games/gosol/sound"
+	"oddstream.games/gosol/ui"
+)
+
+// setting is an entry in the settings drawer: how it looks now, and what changing it does
+type setting struct {
+	name   string
+	show   func() ui.Setting
+	change func(data string) // nil for settings that send a command instead
+}
+
+// redrawCards is what changing the look of the cards needs
+func redrawCards() { TheBaize.setFlag(dirtyCardImages | dirtyPileBackgrounds) }
+
+// resizeCards is what changing the size of the cards needs
+func resizeCards() {
+	TheBaize.setFlag(dirtyCardSizes | dirtyPileBackgrounds | dirtyPilePositions | dirtyCardPositions)
+}
+
+func applyVolume() {
+	if ThePreferences.Mute {
+		sound.SetVolume(0.0)
+	} else {
+		sound.SetVolume(ThePreferences.Volume)
+	}
+}
+
+// settings are shown in the settings drawer in this order
+var settings = []setting{
+	boolSetting("Fixed cards", func(p *Preferences) *bool { return &p.FixedCards }, resizeCards),
+	intSetting("Fixed card width", 50, 150, 2, func(p *Preferences) *int { return &p.FixedCardWidth }, resizeCards),
+	intSetting("Fixed card height", 70, 200, 2, func(p *Preferences) *int { return &p.FixedCardHeight }, resizeCards),
+	floatSetting("Card ratio", 1.2, 1.6, 0.01, "%.2f", func(p *Preferences) *float64 { return &p.CardRatio }, resizeCards),
+	boolSetting("Power moves", func(p *Preferences) *bool { return &p.PowerMoves }, nil),
+	boolSetting("Four colors", func(p *Preferences) *bool { return &p.FourColors }, redrawCards),
+	choiceSetting("Court cards", []string{"Simple", "Illustrated"},
+		func() string {
+			if ThePreferences.IllustratedCourtCards {
+				return "Illustrated"
+			}
+			return "Simple"
+		},
+		func(data string) {
+			ThePreferences.IllustratedCourtCards = data == "Illustrated"
+			redrawCards()
+		}),
+	boolSetting("High contrast", func(p *Preferences) *bool { return &p.HighContrast }, redrawCards),
+	choiceSetting("Colors", PaletteNames,
+		func() string { return ThePreferences.Palette },
+		func(data string) {
+			if ThePreferences.ApplyPalette(data) {
+				redrawCards()
+			}
+		}),
+	colorSetting("Baize color", []string{"BaizeGreen", "DarkGreen", "ForestGreen", "DarkSlateGray", "MidnightBlue", "Navy", "Indigo", "Purple", "Maroon", "SaddleBrown", "DimGray", "Black"},
+		func(p *Preferences) *string { return &p.BaizeColor }, nil),
+	colorSetting("Card face color", []string{"Ivory", "White", "Snow", "FloralWhite", "LightYellow", "LemonChiffron", "PapayaWhip", "Honeydew", "MintCream", "Azure", "WhiteSmoke", "Gainsboro"},
+		func(p *Preferences) *string { return &p.CardFaceColor }, redrawCards),
+	colorSetting("Card back color", []string{"CornflowerBlue", "RoyalBlue", "SteelBlue", "OkabeBlue", "Crimson", "Firebrick", "DarkRed", "ForestGreen", "DarkSlateGray", "Indigo", "Purple", "SaddleBrown"},
+		func(p *Preferences) *string { return &p.CardBackColor }, redrawCards),
+	boolSetting("Mirror baize", func(p *Preferences) *bool { return &p.MirrorBaize }, func() {
+		savedUndoTree := TheBaize.SavableUndoTree()
+		TheBaize.StartFreshGame()
+		if err := TheBaize.SetUndoTree(savedUndoTree); err != nil {
+			log.Println(err)
+		}
+	}),
+	boolSetting("Mute sounds", func(p *Preferences) *bool { return &p.Mute }, applyVolume),
+	floatSetting("Volume", 0, 1, 0.05, "%.2f", func(p *Preferences) *float64 { return &p.Volume }, applyVolume),
+	boolSetting("Bot opponent", func(p *Preferences) *bool { return &p.BotOpponent }, func() {
+		if TheBaize.TwoPlayer() {
+			TheUI.Toast("The opponent changes from the next deal")
+		}
+	}),
+	boolSetting("Show drop targets", func(p *Preferences) *bool { return &p.ShowDropTargets }, nil),
+	boolSetting("Snap drops", func(p *Preferences) *bool { return &p.SnapDrops }, nil),
+	intSetting("Autosave moves", 0, 20, 1, func(p *Preferences) *int { return &p.AutosaveMoves }, nil),
+	commandSetting("Card theme...", "star", "CardThemes"),
+	commandSetting("Key bindings...", "settings", "KeyBindings"),
+	commandSetting("Export profile", "bookmark", "Export"),
+	commandSetting("Import profile", "restore", "Import"),
+}
+
+func boolSetting(name string, field func(*Preferences) *bool, changed func()) setting {
+	return setting{
+		name: name,
+		show: func() ui.Setting {
+			return ui.Setting{Kind: ui.BoolSetting, Name: name, Bool: *field(ThePreferences)}
+		},
+		change: func(data string) {
+			*field(ThePreferences), _ = strconv.ParseBool(data)
+			if changed != nil {
+				changed()
+			}
+		},
+	}
+}
+
+func floatSetting(name string, min, max, step float64, format string, field func(*Preferences) *float64, changed func()) setting {
+	return setting{
+		name: name,
+		show: func() ui.Setting {
+			return ui.Setting{Kind: ui.RangeSetting, Name: name, Value: *field(ThePreferences), Min: min, Max: max, Step: step, Format: format}
+		},
+		change: func(data string) {
+			if f, err := strconv.ParseFloat(data, 64); err == nil && f >= min && f <= max {
+				*field(ThePreferences) = f
+				if changed != nil {
+					changed()
+				}
+			}
+		},
+	}
+}
+
+func intSetting(name string, min, max, step int, field func(*Preferences) *int, changed func()) setting {
+	return setting{
+		name: name,
+		show: func() ui.Setting {
+			return ui.Setting{Kind: ui.RangeSetting, Name: name, Value: float64(*field(ThePreferences)), Min: float64(min), Max: float64(max), Step: float64(step), Format: "%.0f"}
+		},
+		change: func(data string) {
+			// the slider sends floats
+			if f, err := strconv.ParseFloat(data, 64); err == nil && int(f+0.5) >= min && int(f+0.5) <= max {
+				*field(ThePreferences) = int(f + 0.5)
+				if changed != nil {
+					changed()
+				}
+			}
+		},
+	}
+}
+
+func colorSetting(name string, colors []string, field func(*Preferences) *string, changed func()) setting {
+	return setting{
+		name: name,
+		show: func() ui.Setting {
+			swatches := make([]ui.Swatch, 0, len(colors))
+			for _, c := range colors {
+				swatches = append(swatches, ui.Swatch{Name: c, Color: ExtendedColors[c]})
+			}
+			return ui.Setting{Kind: ui.ColorSetting, Name: name, Choice: *field(ThePreferences), Swatches: swatches}
+		},
+		change: func(data string) {
+			if _, ok := ExtendedColors[data]; ok {
+				*field(ThePreferences) = data
+				if changed != nil {
+					changed()
+				}
+			}
+		},
+	}
+}
+
+func choiceSetting(name string, choices []string, get func() string, set func(string)) setting {
+	return setting{
+		name: name,
+		show: func() ui.Setting {
+			return ui.Setting{Kind: ui.ChoiceSetting, Name: name, Choice: get(), Choices: choices}
+		},
+		change: set,
+	}
+}
+
+func commandSetting(name, icon string, cmd ui.Command) setting {
+	return setting{
+		name: name,
+		show: func() ui.Setting {
+			return ui.Setting{Kind: ui.CommandSetting, Name: name, Icon: icon, Command: cmd}
+		},
+	}
+}
+
+// settingsModel describes every setting, with its current value, for the settings drawer
+func settingsModel() []ui.Setting {
+	model := make([]ui.Setting, 0, len(settings))
+	for _, s := range settings {
+		model = append(model, s.show())
+	}
+	return model
+}
+
+// changeSetting applies a change sent by a widget in the settings drawer, returning false if it isn't a setting
+func changeSetting(name, data string) bool {
+	for _, s := range settings {
+		if s.name == name && s.change != nil {
+			s.change(data)
+			ThePreferences.Save()
+			TheUI.UpdateSettingsDrawer(settingsModel())
+			return true
+		}
+	}
+	return false
+}
+
 func ShowSettingsDrawer() {
-	// TODO this pattern is well ugly
-	// consider using callbacks so UI can query each setting
-	var booleanSettings = map[string]bool{
-		"FixedCards":  ThePreferences.FixedCards,
-		"PowerMoves":  ThePreferences.PowerMoves,
-		"FourColors":  ThePreferences.FourColors,
-		"MirrorBaize": ThePreferences.MirrorBaize,
-		"Mute":        ThePreferences.Mute,
-	}
-	TheUI.ShowSettingsDrawer(booleanSettings)
+	TheUI.ShowSettingsDrawer(settingsModel())
 }
@@ -10,7 +10,18 @@ import (
 // Statistics is a container for the statistics for all variants
 type Statistics struct {
 	// PascalCase for JSON
-	StatsMap map[string]*VariantStatistics
+	Version   int // see SchemaVersion
+	Revision  int // bumped whenever a game is recorded, see sync.go
+	StatsMap  map[string]*VariantStatistics
+	VersusMap map[string]*VersusStatistics `json:",omitempty"`
+	Others    map[string]*DeviceStatistics `json:",omitempty"` // statistics synced from other devices, by device ID
+}
+
+// VersusStatistics holds the results of two-player games for one variant and opponent,
+// from the point of view of player 1
+type VersusStatistics struct {
+	// PascalCase for JSON
+	Won, Lost, Abandoned int `json:",omitempty"`
 }
 
 // VariantStatistics holds the statistics for one variant
@@ -66,6 +77,44 @@ func NewStatistics() *Statistics {
 	return s
 }
 
+// variantTotals adds up the statistics for a variant from this device and any others
+func (s *Statistics) variantTotals(v string) *VariantStatistics {
+	totals := &VariantStatistics{}
+	if stats, ok := s.StatsMap[v]; ok {
+		*totals = *stats
+	}
+	for _, ds := range s.Others {
+		stats, ok := ds.StatsMap[v]
+		if !ok {
+			continue
+		}
+		totals.Won += stats.Won
+		totals.Lost += stats.Lost
+		totals.SumPercents += stats.SumPercents
+		totals.BestPercent = util.Max(totals.BestPercent, stats.BestPercent)
+		totals.BestStreak = util.Max(totals.BestStreak, stats.BestStreak)
+		totals.WorstStreak = util.Min(totals.WorstStreak, stats.WorstStreak)
+		// a streak is only kept on one device
+	}
+	return totals
+}
+
+// versusTotals adds up the two-player statistics for a variant from this device and any others
+func (s *Statistics) versusTotals(v string) *VersusStatistics {
+	totals := &VersusStatistics{}
+	if stats, ok := s.VersusMap[versusKey(v)]; ok {
+		*totals = *stats
+	}
+	for _, ds := range s.Others {
+		if stats, ok := ds.VersusMap[versusKey(v)]; ok {
+			totals.Won += stats.Won
+			totals.Lost += stats.Lost
+			totals.Abandoned += stats.Abandoned
+		}
+	}
+	return totals
+}
+
 func (s *Statistics) findVariant(v string) *VariantStatistics {
 	stats, ok := s.StatsMap[v]
 	if !ok {
@@ -78,6 +127,11 @@ func (s *Statistics) findVariant(v string) *VariantStatistics {
 
 func (s *Statistics) RecordWonGame(v string) {
 
+	if TheBaize.TwoPlayer() {
+		s.recordVersusGame(v, TheBaize.script.(TwoPlayerScriptInterface).Winner())
+		return
+	}
+
 	sound.Play("Complete")
 	TheUI.Toast(fmt.Sprintf("Recording completed game of %s", v))
 
@@ -95,8 +149,9 @@ func (s *Statistics) RecordWonGame(v string) {
 	}
 
 	stats.BestPercent = 100
+	s.Revision++
 
-	toasts := stats.generalToasts()
+	toasts := s.variantTotals(v).generalToasts()
 	for _, t := range toasts {
 		TheUI.Toast(t)
 	}
@@ -106,6 +161,11 @@ func (s *Statistics) RecordWonGame(v string) {
 
 func (s *Statistics) RecordLostGame(v string) {
 
+	if TheBaize.TwoPlayer() {
+		s.recordVersusGame(v, 0)
+		return
+	}
+
 	percent := TheBaize.PercentComplete()
 	if percent == 100 {
 		println("*** That's odd, here is a lost game that is 100% complete ***")
@@ -130,16 +190,22 @@ func (s *Statistics) RecordLostGame(v string) {
 		stats.BestPercent = percent
 	}
 	stats.SumPercents += percent
+	s.Revision++
 
 	s.Save()
 }
 
 func (s *Statistics) WelcomeToast(v string) {
 
+	if TheBaize.TwoPlayer() {
+		s.versusWelcomeToast(v)
+		return
+	}
+
 	toasts := []string{}
 
-	stats, ok := s.StatsMap[v]
-	if !ok || stats.Won+stats.Lost == 0 {
+	stats := s.variantTotals(v)
+	if stats.Won+stats.Lost == 0 {
 		toasts = append(toasts, fmt.Sprintf("You have not played %s before", v))
 	} else {
 		avpc := stats.averagePercent()
@@ -158,3 +224,56 @@ func (s *Statistics) WelcomeToast(v string) {
 		TheUI.Toast(t)
 	}
 }
+
+// versusKey returns the key into VersusMap, which keeps games against a bot and a human apart
+func versusKey(v string) string {
+	if TheBaize.BotOpponent() {
+		return v + " v Bot"
+	}
+	return v + " v Human"
+}
+
+func (s *Statistics) findVersus(v string) *VersusStatistics {
+	if s.VersusMap == nil {
+		s.VersusMap = make(map[string]*VersusStatistics)
+	}
+	stats, ok := s.VersusMap[versusKey(v)]
+	if !ok {
+		stats = &VersusStatistics{} // everything 0
+		s.VersusMap[versusKey(v)] = stats
+	}
+	return stats
+}
+
+// recordVersusGame records a two-player game won by winner (1 or 2), or abandoned if winner is 0
+func (s *Statistics) recordVersusGame(v string, winner int) {
+
+	stats := s.findVersus(v)
+
+	switch winner {
+	case 0:
+		TheUI.Toast(fmt.Sprintf("Recording abandoned game of %s", v))
+		stats.Abandoned = stats.Abandoned + 1
+	case 1:
+		sound.Play("Complete")
+		TheUI.Toast(fmt.Sprintf("%s wins", TheBaize.PlayerName(1)))
+		stats.Won = stats.Won + 1
+	default:
+		TheUI.Toast(fmt.Sprintf("%s wins", TheBaize.PlayerName(2)))
+		stats.Lost = stats.Lost + 1
+	}
+	s.Revision++
+	s.versusWelcomeToast(v)
+
+	s.Save()
+}
+
+func (s *Statistics) versusWelcomeToast(v string) {
+	stats := s.versusTotals(v)
+	if stats.Won+stats.Lost+stats.Abandoned == 0 {
+		TheUI.Toast(fmt.Sprintf("You have not played %s against this opponent before", v))
+		return
+	}
+	TheUI.Toast(fmt.Sprintf("Against this opponent, %s has won %d and lost %d (%d abandoned)",
+		TheBaize.PlayerName(1), stats.Won, stats.Lost, stats.Abandoned))
+}
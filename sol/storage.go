@@ -0,0 +1,84 @@
+package sol
+
+import (
+	"sync"
+)
+
+// Storage is somewhere to keep the preferences, statistics and saved games, each under its own key.
+// Implementations must be safe to use from more than one goroutine, as autosaves are written in the background.
+type Storage interface {
+	// Load returns what was saved under key, or nil (and no error) if nothing was
+	Load(key string) ([]byte, error)
+	// Save replaces whatever was saved under key
+	Save(key string, bytes []byte) error
+	// Delete removes key; deleting a key that does not exist is not an error
+	Delete(key string) error
+}
+
+// TheStorage is where everything is loaded from and saved to; if it is nil when NewGame is called,
+// the platform's default (files in the config directory, or the browser's localStorage) is used
+var TheStorage Storage
+
+// StorageDir is a directory to keep files in, instead of the user's config directory,
+// set by command line flag -dir for portable installs
+var StorageDir string
+
+// PrefixStorage keeps everything in the Storage it wraps, with keys starting with Prefix;
+// used to give each player profile its own corner
+type PrefixStorage struct {
+	Storage
+	Prefix string
+}
+
+// Load implements Storage
+func (ps *PrefixStorage) Load(key string) ([]byte, error) {
+	return ps.Storage.Load(ps.Prefix + key)
+}
+
+// Save implements Storage
+func (ps *PrefixStorage) Save(key string, bytes []byte) error {
+	return ps.Storage.Save(ps.Prefix+key, bytes)
+}
+
+// Delete implements Storage
+func (ps *PrefixStorage) Delete(key string) error {
+	return ps.Storage.Delete(ps.Prefix + key)
+}
+
+// MemoryStorage keeps everything in memory, and forgets it when the program exits; used by tests
+type MemoryStorage struct {
+	mu sync.Mutex
+	m  map[string][]byte
+}
+
+// NewMemoryStorage creates an empty MemoryStorage
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{m: make(map[string][]byte)}
+}
+
+// Load implements Storage
+func (ms *MemoryStorage) Load(key string) ([]byte, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	bytes, ok := ms.m[key]
+	if !ok {
+		return nil, nil
+	}
+	return append([]byte(nil), bytes...), nil
+}
+
+// Save implements Storage
+func (ms *MemoryStorage) Save(key string, bytes []byte) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.m[key] = append([]byte(nil), bytes...)
+	return nil
+}
+
+// Delete implements Storage
+func (ms *MemoryStorage) Delete(key string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	delete(ms.m, key)
+	return nil
+}
@@ -0,0 +1,99 @@
+//go:build linux || windows || android
+
+package sol
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// FileStorage keeps each key in a .json file in Dir; a key containing slashes is kept in a subdirectory
+type FileStorage struct {
+	Dir string
+}
+
+// storageDir is StorageDir, or a directory in the user's config directory
+func storageDir() (string, error) {
+	if StorageDir != "" {
+		return StorageDir, nil
+	}
+	// os.Getenv("HOME") == "" on WASM
+	userConfigDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	// println("UserConfigDir", userConfigDir) // /home/gilbert/.config
+	return filepath.Join(userConfigDir, "oddstream.games", "gosol"), nil
+}
+
+// defaultStorage is files in StorageDir, or in the user's config directory
+func defaultStorage() Storage {
+	dir, err := storageDir()
+	if err != nil {
+		// nowhere to keep anything, so carry on without
+		reportLoadProblem("No config directory, nothing will be saved")
+		return NewMemoryStorage()
+	}
+	return &FileStorage{Dir: dir}
+}
+
+func (st *FileStorage) path(key string) string {
+	return filepath.Join(st.Dir, filepath.FromSlash(key)+".json")
+}
+
+// Load implements Storage
+func (st *FileStorage) Load(key string) ([]byte, error) {
+	bytes, err := os.ReadFile(st.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	println("loaded", st.path(key))
+	return bytes, nil
+}
+
+// Save implements Storage. The file is written atomically; the bytes go to a temporary file in the same
+// directory, which is flushed to disk then renamed over the old file, so a crash leaves either the old file or the new one
+func (st *FileStorage) Save(key string, bytes []byte) error {
+	// https://stackoverflow.com/questions/14249467/os-mkdir-and-os-mkdirall-permission-value
+	// if path is already a directory, MkdirAll does nothing and returns nil
+	dir := filepath.Dir(st.path(key))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(dir, filepath.Base(key)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name()) // does nothing once the rename has happened
+
+	if _, err = file.Write(bytes); err != nil {
+		file.Close()
+		return err
+	}
+	if err = file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(file.Name(), st.path(key)); err != nil {
+		return err
+	}
+
+	println("saved", st.path(key))
+	return nil
+}
+
+// Delete implements Storage
+func (st *FileStorage) Delete(key string) error {
+	if err := os.Remove(st.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
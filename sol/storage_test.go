@@ -0,0 +1,71 @@
+package sol
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestStatisticsRecovery(t *testing.T) {
+	TheStorage = NewMemoryStorage()
+	defer func() { TheStorage = nil }()
+
+	s := &Statistics{StatsMap: make(map[string]*VariantStatistics)}
+	s.findVariant("Klondike").Won = 1
+	s.Save()
+	s.findVariant("Klondike").Won = 2
+	s.Save()
+
+	backup, _ := TheStorage.Load(backupName(statisticsKey, 1))
+	if backup == nil {
+		t.Fatal("statistics were not backed up")
+	}
+
+	TheStorage.Save(statisticsKey, []byte("{not json"))
+	s2 := &Statistics{StatsMap: make(map[string]*VariantStatistics)}
+	s2.Load()
+	if won := s2.findVariant("Klondike").Won; won != 1 {
+		t.Errorf("statistics recovered from backup should have 1 win, not %d", won)
+	}
+	if len(loadProblems) == 0 {
+		t.Error("recovery from backup was not reported")
+	}
+	loadProblems = nil
+}
+
+func TestPreferencesLoad(t *testing.T) {
+	TheStorage = NewMemoryStorage()
+	defer func() { TheStorage = nil }()
+
+	// a damaged file must not leave any of its entries in the defaults
+	putBack := ebiten.StandardGamepadButtonRightRight
+	TheStorage.Save(preferencesKey, []byte(fmt.Sprintf(`{"KeyBindings": {"Undo": "X"}, "GamepadButtons": {"%d": "Undo"}, `, putBack)))
+	prefs := DefaultPreferences()
+	prefs.Load()
+	if kb := prefs.KeyBindings["Undo"]; kb != DefaultKeyBindings()["Undo"] {
+		t.Errorf("damaged preferences changed the Undo binding to %q", kb)
+	}
+	if cmd := prefs.GamepadButtons[putBack]; cmd != "PutBack" {
+		t.Errorf("damaged preferences changed the PutBack button to %q", cmd)
+	}
+	loadProblems = nil
+
+	// moving a command to another button takes it off its default button
+	pickUp := ebiten.StandardGamepadButtonRightLeft
+	TheStorage.Save(preferencesKey, []byte(fmt.Sprintf(`{"GamepadButtons": {"%d": "PickUpOrDrop"}}`, pickUp)))
+	prefs = DefaultPreferences()
+	prefs.Load()
+	for btn, cmd := range prefs.GamepadButtons {
+		if cmd == "PickUpOrDrop" && btn != pickUp {
+			t.Errorf("PickUpOrDrop is still on button %d", btn)
+		}
+	}
+	if cmd := prefs.GamepadButtons[ebiten.StandardGamepadButtonRightTop]; cmd != "Collect" {
+		t.Errorf("the Collect button was given %q instead", cmd)
+	}
+	if prefs.KeyBindings["Undo"] != DefaultKeyBindings()["Undo"] {
+		t.Errorf("missing key bindings were not defaulted")
+	}
+	loadProblems = nil
+}
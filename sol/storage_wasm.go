@@ -0,0 +1,71 @@
+// https://github.com/golang/go/wiki/WebAssembly
+// https://pkg.go.dev/syscall/js
+// https://github.com/dennwc/dom
+// "You cannot import "syscall/js" without GOOS=js/GOARCH=wasm"
+// https://github.com/golang/tools/blob/master/gopls/doc/settings.md
+
+package sol
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+// LocalStorage keeps everything in the browser's localStorage, with keys starting with Prefix
+type LocalStorage struct {
+	Prefix string
+}
+
+// defaultStorage is the browser's localStorage; StorageDir means nothing here
+func defaultStorage() Storage {
+	return &LocalStorage{Prefix: "gosol/"}
+}
+
+func (ls *LocalStorage) localStorage() (js.Value, error) {
+	localStorage := js.Global().Get("window").Get("localStorage")
+	if localStorage.IsUndefined() || localStorage.IsNull() {
+		return js.Value{}, fmt.Errorf("no localStorage")
+	}
+	return localStorage, nil
+}
+
+// Load implements Storage
+func (ls *LocalStorage) Load(key string) ([]byte, error) {
+	localStorage, err := ls.localStorage()
+	if err != nil {
+		return nil, err
+	}
+	// https://developer.mozilla.org/en-US/docs/Web/API/Storage/getItem
+	v := localStorage.Call("getItem", ls.Prefix+key)
+	if v.IsUndefined() || v.IsNull() {
+		return nil, nil
+	}
+	return []byte(v.String()), nil
+}
+
+// Save implements Storage
+func (ls *LocalStorage) Save(key string, bytes []byte) (err error) {
+	localStorage, err := ls.localStorage()
+	if err != nil {
+		return err
+	}
+	// setItem throws if the storage is full, which syscall/js turns into a panic
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("saving %s: %v", key, r)
+		}
+	}()
+	localStorage.Call("setItem", ls.Prefix+key, string(bytes))
+	return nil
+}
+
+// Delete implements Storage
+func (ls *LocalStorage) Delete(key string) error {
+	localStorage, err := ls.localStorage()
+	if err != nil {
+		return err
+	}
+	// https://developer.mozilla.org/en-US/docs/Web/API/Storage/removeItem
+	localStorage.Call("removeItem", ls.Prefix+key)
+	return nil
+}
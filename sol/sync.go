@@ -0,0 +1,384 @@
+package sol
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+
+	"oddstream.games/gosol/remote"
+)
+
+// Statistics and preferences can be shared between devices (desktop, browser, phone) by setting
+// Preferences.SyncURL to a key/value store such as the one in cmd/syncserver.
+//
+// Each device only ever changes its own statistics, and bumps Statistics.Revision when it does;
+// the other devices' statistics are kept in Statistics.Others, and the totals shown to the player
+// add them all up. So devices can sync in any order, as often or as rarely as they like, and
+// never have a conflict to resolve. Preferences are simpler; they are stamped with when they were
+// last changed (Preferences.Changed), and the newer copy wins.
+
+// DeviceStatistics are the statistics made on one device
+type DeviceStatistics struct {
+	Revision  int
+	StatsMap  map[string]*VariantStatistics
+	VersusMap map[string]*VersusStatistics `json:",omitempty"`
+}
+
+// deviceLocalPreferences are the preferences that belong to a device, and are not shared
+var deviceLocalPreferences = map[string]bool{"Version": true, "SyncURL": true, "DeviceID": true}
+
+// SyncStorage is a Storage that keeps everything in the Storage it wraps, and also shares the
+// statistics and preferences with other devices through a remote key/value store
+type SyncStorage struct {
+	Storage
+	client   *remote.Client
+	deviceID string
+	// pushes happen in goroutines, which may run in any order, so each save of a key gets a generation,
+	// and a push is dropped if a later save of the same key has already been pushed
+	pushes struct {
+		sync.Mutex
+		made map[string]int
+	}
+	pushing sync.Mutex // held for the whole of a push, so only one is in flight
+	pushed  map[string]int
+	pending map[string]pendingPush // saves not pushed yet, to be pushed after the next pull
+}
+
+// pendingPush is a save that could not be pushed, or that a pull found newer than the remote copy
+type pendingPush struct {
+	generation int
+	bytes      []byte
+}
+
+// pulled is what a background pull fetched from the remote store, waiting for the game loop to merge it
+type pulled struct {
+	ss          *SyncStorage
+	preferences []byte           // shared preferences, or nil if there are none yet
+	statistics  remote.DeviceMap // statistics from every device
+	err         error
+}
+
+// pulls holds pulls that have finished in the background, until the game loop can merge them
+var pulls = make(chan pulled, 1)
+
+// NewSyncStorage wraps local with a SyncStorage that syncs to url as deviceID
+func NewSyncStorage(local Storage, url string, deviceID string) *SyncStorage {
+	ss := &SyncStorage{Storage: local, client: remote.NewClient(url), deviceID: deviceID,
+		pushed: make(map[string]int), pending: make(map[string]pendingPush)}
+	ss.pushes.made = make(map[string]int)
+	return ss
+}
+
+// newDeviceID makes a random ID for this device
+func newDeviceID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		log.Panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// startSync replaces TheStorage with a SyncStorage, if the player has asked for syncing,
+// and starts pulling from the other devices in the background, so a slow or unreachable server
+// doesn't hold up starting the game or switching profiles
+func startSync() {
+	if ThePreferences.SyncURL == "" {
+		return
+	}
+	if ThePreferences.DeviceID == "" {
+		ThePreferences.DeviceID = newDeviceID()
+		ThePreferences.Save()
+	}
+	ss := NewSyncStorage(TheStorage, ThePreferences.SyncURL, ThePreferences.DeviceID)
+	TheStorage = ss
+	local, err := ss.localDevices()
+	if err != nil {
+		log.Println("sync", err)
+		return
+	}
+	go func() {
+		p := ss.fetch(local)
+		pulls <- p
+	}()
+}
+
+// MergePendingPull merges a pull that has finished since the last Update, and reloads
+// the preferences and statistics, as they may have been changed on another device
+func MergePendingPull() {
+	var p pulled
+	select {
+	case p = <-pulls:
+	default:
+		return
+	}
+	if p.ss != TheStorage {
+		return // the player has switched profiles since the pull started
+	}
+	if p.err == nil {
+		p.err = p.ss.merge(p)
+	}
+	if p.err != nil {
+		log.Println("sync", p.err)
+		TheUI.Toast(fmt.Sprintf("Could not sync with %s", ThePreferences.SyncURL))
+		return
+	}
+	go p.ss.pushPending()
+	// carry on with the game being played, whatever the other device was playing
+	variant := ThePreferences.Variant
+	ThePreferences.Load()
+	ThePreferences.Variant = variant
+	applyPreferences()
+	TheStatistics = NewStatistics()
+}
+
+// devices returns the statistics from every device, by device ID, this one included
+func (s *Statistics) devices(deviceID string) (remote.DeviceMap, error) {
+	m := remote.DeviceMap{}
+	mine := &DeviceStatistics{Revision: s.Revision, StatsMap: s.StatsMap, VersusMap: s.VersusMap}
+	for id, ds := range s.Others {
+		if id == deviceID || ds == nil {
+			continue
+		}
+		data, err := json.Marshal(ds)
+		if err != nil {
+			return nil, err
+		}
+		m[id] = &remote.DeviceEntry{Revision: ds.Revision, Data: data}
+	}
+	data, err := json.Marshal(mine)
+	if err != nil {
+		return nil, err
+	}
+	m[deviceID] = &remote.DeviceEntry{Revision: s.Revision, Data: data}
+	return m, nil
+}
+
+// takeDevices takes any newer statistics from m, which may include this device's own,
+// if they were synced from a copy of this device's files that got further
+func (s *Statistics) takeDevices(deviceID string, m remote.DeviceMap) error {
+	for id, e := range m {
+		if e == nil {
+			continue
+		}
+		var ds DeviceStatistics
+		if err := json.Unmarshal(e.Data, &ds); err != nil {
+			return fmt.Errorf("statistics from device %s: %w", id, err)
+		}
+		ds.Revision = e.Revision
+		if ds.StatsMap == nil {
+			ds.StatsMap = make(map[string]*VariantStatistics)
+		}
+		if id == deviceID {
+			if ds.Revision > s.Revision {
+				s.Revision, s.StatsMap, s.VersusMap = ds.Revision, ds.StatsMap, ds.VersusMap
+			}
+			continue
+		}
+		if s.Others == nil {
+			s.Others = make(map[string]*DeviceStatistics)
+		}
+		if old, ok := s.Others[id]; !ok || old.Revision < ds.Revision {
+			s.Others[id] = &ds
+		}
+	}
+	return nil
+}
+
+// sharedPreferences returns the preferences in bytes, less the ones that belong to this device
+func sharedPreferences(bytes []byte) ([]byte, error) {
+	var prefs map[string]json.RawMessage
+	if err := json.Unmarshal(bytes, &prefs); err != nil {
+		return nil, err
+	}
+	for key := range deviceLocalPreferences {
+		delete(prefs, key)
+	}
+	return json.Marshal(prefs)
+}
+
+// Pull brings the statistics and preferences from other devices into local storage
+func (ss *SyncStorage) Pull() error {
+	local, err := ss.localDevices()
+	if err != nil {
+		return err
+	}
+	p := ss.fetch(local)
+	if p.err != nil {
+		return p.err
+	}
+	if err = ss.merge(p); err != nil {
+		return err
+	}
+	ss.pushPending()
+	return nil
+}
+
+// localDevices returns the statistics in local storage, by device ID
+func (ss *SyncStorage) localDevices() (remote.DeviceMap, error) {
+	s, damaged := loadStatistics(ss.Storage, statisticsKey)
+	if damaged || s == nil {
+		// leave damaged statistics to Statistics.Load to recover, and sync them next time
+		s = &Statistics{StatsMap: make(map[string]*VariantStatistics)}
+	}
+	return s.devices(ss.deviceID)
+}
+
+// fetch gets the shared preferences, and swaps statistics with the remote store; it only
+// touches the network, not local storage, so it can run in the background
+func (ss *SyncStorage) fetch(local remote.DeviceMap) pulled {
+	p := pulled{ss: ss}
+	if p.preferences, _, p.err = ss.client.Get(preferencesKey); p.err != nil {
+		return p
+	}
+	p.statistics, p.err = ss.client.SyncDevices(statisticsKey, local)
+	return p
+}
+
+// changedAt returns when the preferences in prefs were changed, or 0 if they don't say
+func changedAt(prefs map[string]json.RawMessage) int64 {
+	var changed int64
+	if value, ok := prefs["Changed"]; ok {
+		_ = json.Unmarshal(value, &changed)
+	}
+	return changed
+}
+
+// merge puts what fetch got into local storage; if the local preferences are newer than
+// the shared ones, they are left to be pushed instead
+func (ss *SyncStorage) merge(p pulled) error {
+	var prefs, remotePrefs map[string]json.RawMessage
+	local, err := ss.Storage.Load(preferencesKey)
+	if err != nil {
+		return err
+	}
+	if local == nil || json.Unmarshal(local, &prefs) != nil {
+		prefs = make(map[string]json.RawMessage)
+	}
+	if p.preferences != nil {
+		if err := json.Unmarshal(p.preferences, &remotePrefs); err != nil {
+			return fmt.Errorf("shared preferences: %w", err)
+		}
+	}
+	switch {
+	case remotePrefs != nil && changedAt(remotePrefs) >= changedAt(prefs):
+		for key, value := range remotePrefs {
+			if !deviceLocalPreferences[key] {
+				prefs[key] = value
+			}
+		}
+		bytes, err := json.MarshalIndent(prefs, "", "\t")
+		if err != nil {
+			return err
+		}
+		if err = ss.Storage.Save(preferencesKey, bytes); err != nil {
+			return err
+		}
+		// an older save that could not be pushed must not replace the newer shared preferences
+		ss.pushing.Lock()
+		delete(ss.pending, preferencesKey)
+		ss.pushing.Unlock()
+	case local != nil:
+		ss.pend(preferencesKey, local)
+	}
+
+	s, damaged := loadStatistics(ss.Storage, statisticsKey)
+	if damaged {
+		return nil // leave it to Statistics.Load to recover, and sync next time
+	}
+	if s == nil {
+		s = &Statistics{StatsMap: make(map[string]*VariantStatistics)}
+	}
+	// the local statistics may have moved on since the fetch; takeDevices keeps whichever is newer
+	if err := s.takeDevices(ss.deviceID, p.statistics); err != nil {
+		return err
+	}
+	s.Version = SchemaVersion
+	bytes, err := json.MarshalIndent(s, "", "\t")
+	if err != nil {
+		return err
+	}
+	return ss.Storage.Save(statisticsKey, bytes)
+}
+
+// Save implements Storage; the statistics and preferences are pushed to the remote store in the background
+func (ss *SyncStorage) Save(key string, bytes []byte) error {
+	if err := ss.Storage.Save(key, bytes); err != nil {
+		return err
+	}
+	if key == statisticsKey || key == preferencesKey {
+		ss.pushes.Lock()
+		ss.pushes.made[key]++
+		generation := ss.pushes.made[key]
+		ss.pushes.Unlock()
+		go ss.push(key, generation, bytes)
+	}
+	return nil
+}
+
+// push sends the statistics or preferences in bytes to the remote store,
+// unless a later save of them has been pushed already
+func (ss *SyncStorage) push(key string, generation int, bytes []byte) {
+	ss.pushing.Lock()
+	defer ss.pushing.Unlock()
+	if generation <= ss.pushed[key] {
+		if ss.pending[key].generation <= ss.pushed[key] {
+			delete(ss.pending, key)
+		}
+		return
+	}
+	var err error
+	switch key {
+	case preferencesKey:
+		var shared []byte
+		if shared, err = sharedPreferences(bytes); err == nil {
+			err = ss.client.Put(key, shared, "")
+		}
+	case statisticsKey:
+		var s Statistics
+		if err = json.Unmarshal(bytes, &s); err == nil {
+			var local remote.DeviceMap
+			if local, err = s.devices(ss.deviceID); err == nil {
+				// what the other devices have done is picked up by Pull, next time the game starts
+				_, err = ss.client.SyncDevices(key, local)
+			}
+		}
+	}
+	if err != nil {
+		log.Println("sync push", key, err)
+		if generation > ss.pending[key].generation {
+			ss.pending[key] = pendingPush{generation: generation, bytes: bytes}
+		}
+		return
+	}
+	ss.pushed[key] = generation
+	if ss.pending[key].generation <= generation {
+		delete(ss.pending, key)
+	}
+}
+
+// pend gives bytes, which are already in local storage, a new generation and leaves them to be pushed after the pull
+func (ss *SyncStorage) pend(key string, bytes []byte) {
+	ss.pushes.Lock()
+	ss.pushes.made[key]++
+	generation := ss.pushes.made[key]
+	ss.pushes.Unlock()
+	ss.pushing.Lock()
+	ss.pending[key] = pendingPush{generation: generation, bytes: bytes}
+	ss.pushing.Unlock()
+}
+
+// pushPending pushes the saves that are still pending, after a pull has shown the remote store can be reached
+func (ss *SyncStorage) pushPending() {
+	ss.pushing.Lock()
+	pending := make(map[string]pendingPush, len(ss.pending))
+	for key, pp := range ss.pending {
+		pending[key] = pp
+	}
+	ss.pushing.Unlock()
+	for key, pp := range pending {
+		ss.push(key, pp.generation, pp.bytes)
+	}
+}
@@ -0,0 +1,106 @@
+package sol
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"oddstream.games/gosol/remote"
+)
+
+// playOn records won games of Klondike in a device's local storage, as if they had been played there
+func playOn(t *testing.T, local Storage, won int) {
+	s := &Statistics{Revision: won, StatsMap: map[string]*VariantStatistics{"Klondike": {Won: won}}}
+	bytes, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	local.Save(statisticsKey, bytes)
+}
+
+func TestSyncStatistics(t *testing.T) {
+	ts := httptest.NewServer(remote.NewServer(""))
+	defer ts.Close()
+
+	desktop := NewSyncStorage(NewMemoryStorage(), ts.URL+"/player", "desktop")
+	phone := NewSyncStorage(NewMemoryStorage(), ts.URL+"/player", "phone")
+
+	playOn(t, desktop.Storage, 2)
+	if err := desktop.Pull(); err != nil {
+		t.Fatal(err)
+	}
+	playOn(t, phone.Storage, 3)
+	if err := phone.Pull(); err != nil {
+		t.Fatal(err)
+	}
+	if err := desktop.Pull(); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, ss := range map[string]*SyncStorage{"desktop": desktop, "phone": phone} {
+		s, damaged := loadStatistics(ss.Storage, statisticsKey)
+		if damaged || s == nil {
+			t.Fatalf("%s statistics were not saved", name)
+		}
+		if won := s.variantTotals("Klondike").Won; won != 5 {
+			t.Errorf("%s should show 5 wins in total, not %d", name, won)
+		}
+	}
+}
+
+// changePreferences saves preferences with the given baize color, changed at the given time, to local
+func changePreferences(t *testing.T, local Storage, color string, changed int64) {
+	prefs := DefaultPreferences()
+	prefs.BaizeColor, prefs.Changed = color, changed
+	bytes, err := json.Marshal(prefs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	local.Save(preferencesKey, bytes)
+}
+
+func TestSyncPreferences(t *testing.T) {
+	ts := httptest.NewServer(remote.NewServer(""))
+	defer ts.Close()
+
+	desktop := NewSyncStorage(NewMemoryStorage(), ts.URL+"/player", "desktop")
+	phone := NewSyncStorage(NewMemoryStorage(), ts.URL+"/player", "phone")
+	baizeColor := func(ss *SyncStorage) string {
+		bytes, err := ss.Storage.Load(preferencesKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var prefs Preferences
+		if err = json.Unmarshal(bytes, &prefs); err != nil {
+			t.Fatal(err)
+		}
+		return prefs.BaizeColor
+	}
+
+	// the first device to sync shares its preferences
+	changePreferences(t, desktop.Storage, "Red", 200)
+	if err := desktop.Pull(); err != nil {
+		t.Fatal(err)
+	}
+	// an older change on another device loses to the shared preferences
+	changePreferences(t, phone.Storage, "Blue", 100)
+	if err := phone.Pull(); err != nil {
+		t.Fatal(err)
+	}
+	if color := baizeColor(phone); color != "Red" {
+		t.Errorf("phone kept its older %s baize", color)
+	}
+	// a newer change is kept, and shared
+	changePreferences(t, phone.Storage, "Green", 300)
+	if err := phone.Pull(); err != nil {
+		t.Fatal(err)
+	}
+	if err := desktop.Pull(); err != nil {
+		t.Fatal(err)
+	}
+	for name, ss := range map[string]*SyncStorage{"desktop": desktop, "phone": phone} {
+		if color := baizeColor(ss); color != "Green" {
+			t.Errorf("%s has a %s baize, not the newer Green", name, color)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package sol
+
+import "time"
+
+// Tapping the same card again, soon after it was moved by a tap, moves it to the next best destination instead;
+// tapping past the last destination puts it back where it started. The whole cycle is a single undo step.
+
+const tapCycleDuration = 1500 * time.Millisecond
+
+type tapCycle struct {
+	card    *Card
+	src     *Pile     // where the card was when it was first tapped
+	dsts    []*Pile   // ranked destinations of the card from src
+	index   int       // index into dsts of where the card is now
+	undoLen int       // length of the undo stack after the latest tap in the cycle
+	when    time.Time // time of the latest tap in the cycle
+}
+
+// moveTailTo moves card, and any cards on top of it, to dst
+func moveTailTo(card *Card, dst *Pile) {
+	if card == card.owner.Peek() {
+		MoveCard(card.owner, dst)
+	} else {
+		MoveTail(card, dst)
+	}
+}
+
+// StartTapCycle remembers that card has been tapped from src to the first of dsts
+func (b *Baize) StartTapCycle(card *Card, src *Pile, dsts []*Pile) {
+	if len(dsts) < 2 || b.TwoPlayer() {
+		b.tapCycle = tapCycle{}
+		return
+	}
+	// AfterUserMove will push the new state onto the undo stack
+	b.tapCycle = tapCycle{card: card, src: src, dsts: dsts, undoLen: len(b.undoStack) + 1, when: time.Now()}
+}
+
+// ContinueTapCycle moves card to its next destination, if it's being tapped again;
+// returns false if this tap is not part of a cycle
+func (b *Baize) ContinueTapCycle(card *Card) bool {
+	tc := &b.tapCycle
+	if tc.card != card || time.Since(tc.when) > tapCycleDuration || len(b.undoStack) != tc.undoLen || tc.undoLen < 2 {
+		return false
+	}
+	// put everything back as it was before the first tap, so the cycle stays one undo step
+	b.UndoDiscard()
+	b.restoreSavable(b.UndoPeek())
+	for tc.index++; tc.index < len(tc.dsts); tc.index++ {
+		if ok, _ := tc.dsts[tc.index].vtable.CanAcceptTail(tc.src.MakeTail(card)); ok {
+			moveTailTo(card, tc.dsts[tc.index])
+			tc.when = time.Now()
+			return true
+		}
+	}
+	// cycled through all the destinations, so leave the card where it started;
+	// AfterUserMove will push the original state back
+	b.UndoPop()
+	b.tapCycle = tapCycle{}
+	return true
+}
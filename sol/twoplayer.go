@@ -0,0 +1,134 @@
+package sol
+
+import (
+	"fmt"
+)
+
+// TwoPlayerScriptInterface is implemented by competitive variants (eg Russian Bank),
+// where each player owns some piles (see Pile.Player), the other piles are shared,
+// and the players take turns. Player 1 is always a local human; player 2 is
+// another local human, or a bot if ThePreferences.BotOpponent was set when the game was dealt.
+type TwoPlayerScriptInterface interface {
+	// Winner returns the player (1 or 2) that has won, or 0 if the game is still going
+	Winner() int
+	// BotEndTurn makes the move that ends the bot's turn, when it can find nothing better to do
+	BotEndTurn()
+}
+
+// LoadingScriptInterface is implemented by two-player variants (eg Russian Bank) where a player
+// may get rid of cards by adding them to the other player's Waste or Reserve
+type LoadingScriptInterface interface {
+	// LoadError says whether card can be loaded onto dst, which belongs to the other player
+	LoadError(dst *Pile, card *Card) (bool, error)
+}
+
+// loader returns the script, if it allows cards to be loaded onto dst because dst belongs to the other player
+func loader(dst *Pile) LoadingScriptInterface {
+	if dst.player == 0 || dst.player == TheBaize.Turn() {
+		return nil
+	}
+	ls, _ := TheBaize.script.(LoadingScriptInterface)
+	return ls
+}
+
+const botDelay = 30 // ticks between bot moves, so the human can see what it's doing
+
+// TwoPlayer returns true if the current variant is a two-player game
+func (b *Baize) TwoPlayer() bool {
+	_, ok := b.script.(TwoPlayerScriptInterface)
+	return ok
+}
+
+// StartTurns gives the first turn to player 1 in a two-player game, and decides whether player 2
+// is a bot; changing the setting mid-game would muddle the statistics, so it waits for the next deal
+func (b *Baize) StartTurns() {
+	if b.TwoPlayer() {
+		b.turn = 1
+	} else {
+		b.turn = 0
+	}
+	b.bot = b.turn != 0 && ThePreferences.BotOpponent
+	b.botTicks = 0
+}
+
+// BotOpponent returns true if player 2 is a bot in this game
+func (b *Baize) BotOpponent() bool {
+	return b.bot
+}
+
+// Turn returns the player (1 or 2) whose turn it is, or 0 if this isn't a two-player game
+func (b *Baize) Turn() int {
+	return b.turn
+}
+
+// EndTurn passes the turn to the other player; called by scripts from AfterMove
+func (b *Baize) EndTurn() {
+	b.turn = 3 - b.turn
+	b.botTicks = 0
+	TheUI.Toast(fmt.Sprintf("%s to move", b.PlayerName(b.turn)))
+}
+
+func (b *Baize) PlayerName(player int) string {
+	if player == 2 && b.bot {
+		return "Bot"
+	}
+	return fmt.Sprintf("Player %d", player)
+}
+
+// BotsTurn returns true if the bot should be moving
+func (b *Baize) BotsTurn() bool {
+	return b.turn == 2 && b.bot && !b.Complete()
+}
+
+// LenBeforeMove returns the number of cards a pile had before the current move,
+// so a script's AfterMove can tell what kind of move was made
+// (AfterMove is called before the new state is pushed onto the undo stack)
+func (b *Baize) LenBeforeMove(pile *Pile) int {
+	if sav := b.UndoPeek(); sav != nil {
+		for i, p := range b.piles {
+			if p == pile {
+				return len(sav.Piles[i].Cards)
+			}
+		}
+	}
+	return pile.Len()
+}
+
+// findBotMove looks for a top card that the bot can move to a foundation,
+// or failing that, load onto the other player's piles
+func (b *Baize) findBotMove() (*Card, *Pile) {
+	var loadCard *Card
+	var loadDst *Pile
+	for _, p := range b.piles {
+		if p.Empty() || p.player != b.turn && p.category != "Tableau" {
+			continue
+		}
+		card := p.Peek()
+		for _, dst := range b.FindHomesForTail([]*Card{card}) {
+			if dst.category == "Foundation" {
+				return card, dst
+			}
+			if loadCard == nil && dst.player != 0 && dst.player != b.turn {
+				loadCard, loadDst = card, dst
+			}
+		}
+	}
+	return loadCard, loadDst
+}
+
+// BotMove makes one move for the bot, which is a simple soul:
+// it moves a card to a foundation if it can, or loads one onto the other player's piles,
+// otherwise it lets the script end it's turn
+func (b *Baize) BotMove() {
+	crc := b.CRC()
+	if card, dst := b.findBotMove(); card != nil {
+		MoveCard(card.Owner(), dst)
+	} else {
+		b.script.(TwoPlayerScriptInterface).BotEndTurn()
+	}
+	if crc == b.CRC() {
+		// the script couldn't find a move either, so give up
+		b.EndTurn()
+	}
+	b.AfterUserMove()
+}
@@ -19,8 +19,10 @@ type SavablePile struct {
 
 type SavableBaize struct {
 	Piles    []*SavablePile `json:",omitempty"`
-	Bookmark int            `json:",omitempty"`
 	Recycles int            `json:",omitempty"`
+	Seed     int64          `json:",omitempty"`
+	Turn     int            `json:",omitempty"`
+	Bot      bool           `json:",omitempty"` // player 2 is a bot, see Baize.BotOpponent
 }
 
 func (self *Pile) Savable() *SavablePile {
@@ -59,7 +61,7 @@ func (self *Pile) UpdateFromSavable(sp *SavablePile) {
 }
 
 func (b *Baize) NewSavableBaize() *SavableBaize {
-	ss := &SavableBaize{Bookmark: b.bookmark, Recycles: b.recycles}
+	ss := &SavableBaize{Recycles: b.recycles, Seed: b.seed, Turn: b.turn, Bot: b.bot}
 	for _, p := range b.piles {
 		ss.Piles = append(ss.Piles, p.Savable())
 	}
@@ -68,7 +70,7 @@ func (b *Baize) NewSavableBaize() *SavableBaize {
 
 func (b *Baize) UndoPush() {
 	ss := b.NewSavableBaize()
-	b.undoStack = append(b.undoStack, ss)
+	b.undoStack = append(b.undoStack, b.addUndoNode(ss))
 }
 
 func (b *Baize) UndoPeek() *SavableBaize {
@@ -92,11 +94,21 @@ func (b *Baize) UpdateFromSavable(sb *SavableBaize) {
 		log.Panic("Baize piles and SavableBaize piles are different")
 	}
 	sound.Play("OpenPackage")
+	b.restoreSavable(sb)
+}
+
+// restoreSavable is UpdateFromSavable without the fanfare
+func (b *Baize) restoreSavable(sb *SavableBaize) {
 	for i := 0; i < len(sb.Piles); i++ {
 		b.piles[i].UpdateFromSavable(sb.Piles[i])
 	}
-	b.bookmark = sb.Bookmark
 	b.recycles = sb.Recycles
+	b.turn = sb.Turn
+	b.bot = sb.Bot
+	if sb.Seed != 0 {
+		b.seed = sb.Seed
+	}
+	b.pairCard = nil
 	b.setFlag(dirtyCardPositions)
 }
 
@@ -111,6 +123,10 @@ func (b *Baize) Undo() {
 		TheUI.Toast("Cannot undo a completed game") // otherwise the stats can be cooked
 		return
 	}
+	if b.TwoPlayer() && b.undoStack[len(b.undoStack)-2].Turn != b.turn {
+		TheUI.Toast("Cannot undo the other player's move")
+		return
+	}
 	_, ok := b.UndoPop() // removes current state
 	if !ok {
 		log.Panic("error popping current state from undo stack")
@@ -136,44 +152,6 @@ func (b *Baize) RestartDeal() {
 		}
 	}
 	b.UpdateFromSavable(sav)
-	b.bookmark = 0 // do this AFTER UpdateFromSavable
-
-	b.UndoPush() // replace current state
-	b.FindDestinations()
-	b.UpdateStatusbar()
-}
-
-// SavePosition saves the current Baize state
-func (b *Baize) SavePosition() {
-	if b.Complete() {
-		TheUI.Toast("Cannot bookmark a completed game") // otherwise the stats can be cooked
-		sound.Play("Blip")
-		return
-	}
-	b.bookmark = len(b.undoStack)
-	sb := b.UndoPeek()
-	sb.Bookmark = b.bookmark
-	sb.Recycles = b.recycles
-	TheUI.Toast("Position bookmarked")
-}
-
-// LoadPosition loads a previously saved Baize state
-func (b *Baize) LoadPosition() {
-	if b.bookmark == 0 || b.bookmark > len(b.undoStack) || b.Complete() {
-		// println("bookmark", b.bookmark, "undostack", len(b.undoStack))
-		TheUI.Toast("No bookmark")
-		sound.Play("Blip")
-		return
-	}
-	var sav *SavableBaize
-	var ok bool
-	for len(b.undoStack)+1 > b.bookmark {
-		sav, ok = b.UndoPop()
-		if !ok {
-			log.Panic("error popping from undo stack")
-		}
-	}
-	b.UpdateFromSavable(sav)
 	b.UndoPush() // replace current state
 	b.FindDestinations()
 	b.UpdateStatusbar()
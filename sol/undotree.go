@@ -0,0 +1,225 @@
+package sol
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// The undo stack is the path through a tree of positions, from the deal to the current position.
+// Undoing, then making a different move, starts a new branch; the old branch stays in the tree,
+// and the player can go back to any position in it with GotoUndoNode.
+
+// UndoNode is a position in the undo tree
+type UndoNode struct {
+	State  *SavableBaize
+	Parent int // index of the parent node in the tree, -1 for the deal
+}
+
+// SavableUndoTree is what gets saved in saved.json
+type SavableUndoTree struct {
+	Variant   string
+	Nodes     []*UndoNode
+	Path      []int       // indexes of the nodes from the deal to the current position
+	Bookmarks []*Bookmark `json:",omitempty"`
+}
+
+// SamePosition returns true if the two states have the same cards in the same piles
+func (sb *SavableBaize) SamePosition(other *SavableBaize) bool {
+	if len(sb.Piles) != len(other.Piles) || sb.Recycles != other.Recycles || sb.Turn != other.Turn {
+		return false
+	}
+	for i, sp := range sb.Piles {
+		op := other.Piles[i]
+		if len(sp.Cards) != len(op.Cards) {
+			return false
+		}
+		for j, cid := range sp.Cards {
+			if cid != op.Cards[j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// undoNodeIndex returns the index of the tree node holding sb, or -1
+func (b *Baize) undoNodeIndex(sb *SavableBaize) int {
+	for i, n := range b.undoNodes {
+		if n.State == sb {
+			return i
+		}
+	}
+	return -1
+}
+
+// undoChildren returns the indexes of the nodes whose parent is node n
+func (b *Baize) undoChildren(n int) []int {
+	var children []int
+	for i, node := range b.undoNodes {
+		if node.Parent == n {
+			children = append(children, i)
+		}
+	}
+	return children
+}
+
+// addUndoNode puts ss into the tree as a child of the current position,
+// unless that child already exists, in which case the existing branch is followed
+func (b *Baize) addUndoNode(ss *SavableBaize) *SavableBaize {
+	parent := -1
+	if top := b.UndoPeek(); top != nil {
+		parent = b.undoNodeIndex(top)
+	}
+	for _, i := range b.undoChildren(parent) {
+		if b.undoNodes[i].State.SamePosition(ss) {
+			return b.undoNodes[i].State
+		}
+	}
+	b.undoNodes = append(b.undoNodes, &UndoNode{State: ss, Parent: parent})
+	return ss
+}
+
+// UndoDiscard pops the current state, and removes it from the tree if it was the last position added
+// and nothing follows it; used when a move is replaced rather than undone
+func (b *Baize) UndoDiscard() {
+	sav, ok := b.UndoPop()
+	if !ok {
+		return
+	}
+	last := len(b.undoNodes) - 1
+	if last >= 0 && b.undoNodes[last].State == sav && len(b.undoChildren(last)) == 0 {
+		b.undoNodes = b.undoNodes[:last]
+	}
+}
+
+// UndoBranches returns the number of leaf positions in the tree
+func (b *Baize) UndoBranches() int {
+	var leaves int
+	for i := range b.undoNodes {
+		if len(b.undoChildren(i)) == 0 {
+			leaves++
+		}
+	}
+	return leaves
+}
+
+// undoPath returns the positions from the deal to tree node n
+func (b *Baize) undoPath(n int) []*SavableBaize {
+	var path []*SavableBaize
+	for i := n; i != -1; i = b.undoNodes[i].Parent {
+		path = append([]*SavableBaize{b.undoNodes[i].State}, path...)
+	}
+	return path
+}
+
+// GotoUndoNode makes the tree node n the current position
+func (b *Baize) GotoUndoNode(n int) {
+	if n < 0 || n >= len(b.undoNodes) {
+		return
+	}
+	if b.Complete() {
+		TheUI.Toast("Cannot change a completed game") // otherwise the stats can be cooked
+		return
+	}
+	if b.TwoPlayer() {
+		TheUI.Toast("Cannot change branches in a two player game")
+		return
+	}
+	b.undoStack = b.undoPath(n)
+	b.UpdateFromSavable(b.UndoPeek())
+	b.FindDestinations()
+	b.UpdateStatusbar()
+}
+
+// SavableUndoTree returns the undo tree, and the path to the current position, for saving
+func (b *Baize) SavableUndoTree() *SavableUndoTree {
+	tree := &SavableUndoTree{Variant: ThePreferences.Variant, Nodes: b.undoNodes, Bookmarks: b.bookmarks}
+	for _, sb := range b.undoStack {
+		tree.Path = append(tree.Path, b.undoNodeIndex(sb))
+	}
+	return tree
+}
+
+// SetUndoTree replaces the undo tree, and makes the end of its path the current position.
+// The tree is checked first, so a game saved from another variant cannot replace this one.
+func (b *Baize) SetUndoTree(tree *SavableUndoTree) error {
+	if err := b.checkUndoTree(tree); err != nil {
+		return err
+	}
+	b.undoNodes = tree.Nodes
+	b.bookmarks = tree.Bookmarks
+	b.undoStack = nil
+	for _, i := range tree.Path {
+		b.undoStack = append(b.undoStack, b.undoNodes[i].State)
+	}
+	sav := b.UndoPeek()
+	b.UpdateFromSavable(sav)
+	b.FindDestinations()
+	if b.Complete() {
+		TheUI.Toast("Complete")
+		TheUI.ShowFAB("star", "NewDeal")
+		b.StartSpinning()
+	} else if b.Conformant() {
+		TheUI.ShowFAB("done_all", "Collect")
+	} else if b.moves == 0 {
+		TheUI.Toast("No movable cards")
+		TheUI.ShowFAB("star", "NewDeal")
+	} else {
+		TheUI.HideFAB()
+	}
+	b.UpdateStatusbar()
+	return nil
+}
+
+// ParseUndoTree unmarshals saved.json, which may be an undo tree or (from older versions) a plain undo stack
+func ParseUndoTree(bytes []byte) (*SavableUndoTree, error) {
+	var tree SavableUndoTree
+	if err := json.Unmarshal(bytes, &tree); err != nil {
+		var undoStack []*SavableBaize
+		if err2 := json.Unmarshal(bytes, &undoStack); err2 != nil {
+			return nil, err
+		}
+		// a plain undo stack is a tree with one branch
+		for i, sb := range undoStack {
+			tree.Nodes = append(tree.Nodes, &UndoNode{State: sb, Parent: i - 1})
+			tree.Path = append(tree.Path, i)
+		}
+	}
+	if len(tree.Path) == 0 {
+		return nil, nil
+	}
+	if err := tree.validate(); err != nil {
+		return nil, err
+	}
+	return &tree, nil
+}
+
+// validate checks that the tree's nodes, bookmarks and path refer to each other sensibly
+func (tree *SavableUndoTree) validate() error {
+	if len(tree.Path) == 0 {
+		return errors.New("undo tree has no path")
+	}
+	for i, n := range tree.Nodes {
+		if n == nil || n.State == nil || n.Parent < -1 || n.Parent >= i {
+			return fmt.Errorf("undo tree node %d is not valid", i)
+		}
+	}
+	for i, bm := range tree.Bookmarks {
+		if bm == nil || bm.State == nil {
+			return fmt.Errorf("bookmark %d is not valid", i)
+		}
+	}
+	// the path must lead from the deal, through each node's children, like an undo stack
+	parent := -1
+	for _, i := range tree.Path {
+		if i < 0 || i >= len(tree.Nodes) {
+			return fmt.Errorf("undo tree path index %d is not valid", i)
+		}
+		if tree.Nodes[i].Parent != parent {
+			return fmt.Errorf("undo tree path goes to node %d, which is not a child of node %d", i, parent)
+		}
+		parent = i
+	}
+	return nil
+}
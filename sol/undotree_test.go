@@ -0,0 +1,70 @@
+package sol
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUndoTree(t *testing.T) {
+	b := &Baize{}
+	// positions are told apart by their recycles, as the baize has no piles
+	push := func(position int) {
+		b.undoStack = append(b.undoStack, b.addUndoNode(&SavableBaize{Recycles: position}))
+	}
+	positions := func(path []*SavableBaize) []int {
+		var ps []int
+		for _, sb := range path {
+			ps = append(ps, sb.Recycles)
+		}
+		return ps
+	}
+
+	push(0) // the deal
+	push(1)
+	push(2)
+	b.UndoPop() // undo the move to 2
+	push(3)     // a different move starts a new branch
+	if len(b.undoNodes) != 4 || b.UndoBranches() != 2 {
+		t.Fatalf("tree has %d nodes and %d branches, expected 4 and 2", len(b.undoNodes), b.UndoBranches())
+	}
+	b.UndoPop()
+	push(3) // the same move again follows the existing branch
+	if len(b.undoNodes) != 4 {
+		t.Errorf("repeating a move added a node, tree has %d", len(b.undoNodes))
+	}
+
+	if got := positions(b.undoPath(2)); len(got) != 3 || got[0] != 0 || got[1] != 1 || got[2] != 2 {
+		t.Errorf("path to node 2 goes through %v", got)
+	}
+	b.undoStack = b.undoPath(2)
+
+	bytes, err := json.Marshal(b.SavableUndoTree())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree, err := ParseUndoTree(bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tree.Nodes) != 4 || tree.Nodes[3].Parent != 1 {
+		t.Errorf("saved tree has %d nodes, and the new branch has parent %d", len(tree.Nodes), tree.Nodes[3].Parent)
+	}
+	if p := tree.Path; len(p) != 3 || tree.Nodes[p[2]].State.Recycles != 2 {
+		t.Errorf("saved path %v does not end at position 2", p)
+	}
+
+	// older versions saved a plain undo stack
+	tree, err = ParseUndoTree([]byte(`[{"Recycles": 1}, {"Recycles": 2}]`))
+	if err != nil || len(tree.Path) != 2 || tree.Nodes[1].Parent != 0 {
+		t.Errorf("plain undo stack parsed as %v, %v", tree, err)
+	}
+	if _, err := ParseUndoTree([]byte(`{"Nodes": [{"State": {}, "Parent": 0}], "Path": [0]}`)); err == nil {
+		t.Error("a node that is its own parent should fail")
+	}
+	if _, err := ParseUndoTree([]byte(`{"Nodes": [{"State": {}, "Parent": -1}, {"State": {}, "Parent": 0}, {"State": {}, "Parent": 0}], "Path": [0, 1, 2]}`)); err == nil {
+		t.Error("a path that jumps between branches should fail")
+	}
+	if _, err := ParseUndoTree([]byte(`{"Nodes": [{"State": {}, "Parent": -1}, {"State": {}, "Parent": 0}], "Path": [1]}`)); err == nil {
+		t.Error("a path that doesn't start at the deal should fail")
+	}
+}
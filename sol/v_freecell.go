@@ -101,6 +101,19 @@ func (*Freecell) UnsortedPairs(pile *Pile) int {
 	return UnsortedPairs(pile, CardPair.Compare_DownAltColor)
 }
 
+// DestinationWeight prefers building on the tableau, then a cell, keeping empty columns for last as they are worth more
+func (*Freecell) DestinationWeight(card *Card, dst *Pile) int {
+	switch {
+	case dst.category == "Foundation":
+		return 3
+	case dst.category == "Tableau" && !dst.Empty():
+		return 2
+	case dst.category == "Cell":
+		return 1
+	}
+	return 0
+}
+
 func (*Freecell) TailTapped(tail []*Card) {
 	tail[0].Owner().vtable.TailTapped(tail)
 }
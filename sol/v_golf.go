@@ -0,0 +1,104 @@
+package sol
+
+//lint:file-ignore ST1005 Error messages are toasted, so need to be capitalized
+
+import (
+	"errors"
+	"image"
+)
+
+// golfCardsPerTableau is how many cards are dealt to each tableau
+const golfCardsPerTableau = 5
+
+type Golf struct {
+	ScriptBase
+}
+
+func (*Golf) Info() *VariantInfo {
+	return &VariantInfo{
+		windowShape: "square",
+		wikipedia:   "https://en.wikipedia.org/wiki/Golf_(patience)",
+	}
+}
+
+func (g *Golf) BuildPiles() {
+
+	g.stock = NewStock(image.Point{0, 0}, FAN_NONE, 1, 4, nil, 0)
+
+	g.foundations = []*Pile{NewFoundation(image.Point{1, 0})}
+
+	g.tableaux = nil
+	for x := 0; x < 7; x++ {
+		g.tableaux = append(g.tableaux, NewTableau(image.Point{x, 1}, FAN_DOWN, MOVE_ONE))
+	}
+}
+
+func (g *Golf) StartGame() {
+	for _, pile := range g.tableaux {
+		for i := 0; i < golfCardsPerTableau; i++ {
+			MoveCard(g.stock, pile)
+		}
+	}
+	TheBaize.SetRecycles(0)
+	MoveCard(g.stock, g.foundations[0])
+}
+
+func (*Golf) AfterMove() {
+}
+
+// Complete is true when the tableaux have been cleared, whatever is left in the stock
+func (g *Golf) Complete() bool {
+	return pilesCleared(g.tableaux)
+}
+
+func (g *Golf) PercentComplete() int {
+	return percentCleared(g.tableaux, len(g.tableaux)*golfCardsPerTableau)
+}
+
+func (*Golf) TailMoveError(tail []*Card) (bool, error) {
+	return true, nil
+}
+
+func (*Golf) TailAppendError(dst *Pile, tail []*Card) (bool, error) {
+	switch dst.category {
+	case "Foundation":
+		if tail[0].Owner().IsStock() {
+			return true, nil
+		}
+		if dst.Empty() {
+			return true, nil
+		}
+		c1, c2 := dst.Peek().Ordinal(), tail[0].Ordinal()
+		if c1 == 13 {
+			return false, errors.New("Cannot add a card to a King")
+		}
+		if c1-c2 == 1 || c2-c1 == 1 {
+			return true, nil
+		}
+		return false, errors.New("The card must be one higher or one lower than the Foundation card")
+	case "Tableau":
+		return false, errors.New("Cannot move cards to a Tableau")
+	}
+	return true, nil
+}
+
+func (*Golf) UnsortedPairs(pile *Pile) int {
+	// tableau cards are never built into sequences, they are only removed
+	if pile.Empty() {
+		return 0
+	}
+	return pile.Len() - 1
+}
+
+func (g *Golf) TailTapped(tail []*Card) {
+	var pile *Pile = tail[0].Owner()
+	if pile == g.stock && len(tail) == 1 {
+		MoveCard(g.stock, g.foundations[0])
+	} else {
+		pile.vtable.TailTapped(tail)
+	}
+}
+
+func (*Golf) PileTapped(*Pile) {
+	// no recycles
+}
@@ -0,0 +1,101 @@
+package sol
+
+//lint:file-ignore ST1005 Error messages are toasted, so need to be capitalized
+
+import (
+	"image"
+)
+
+type LaBelleLucie struct {
+	ScriptBase
+	trefoil bool
+}
+
+func (*LaBelleLucie) Info() *VariantInfo {
+	return &VariantInfo{
+		windowShape: "square",
+		wikipedia:   "https://en.wikipedia.org/wiki/La_Belle_Lucie",
+	}
+}
+
+func (lbl *LaBelleLucie) BuildPiles() {
+
+	// the stock is only used to gather and shuffle cards during a redeal,
+	// but is visible so it can show the number of redeals remaining and be tapped
+	lbl.stock = NewStock(image.Point{0, 0}, FAN_NONE, 1, 4, nil, 0)
+
+	lbl.foundations = nil
+	for x := 5; x < 9; x++ {
+		f := NewFoundation(image.Point{x, 0})
+		lbl.foundations = append(lbl.foundations, f)
+		f.SetLabel("A")
+	}
+
+	// La Belle Lucie has 18 fans, Trefoil has 16 (because the Aces start on the foundations)
+	var fansPerRow int = 9
+	if lbl.trefoil {
+		fansPerRow = 8
+	}
+	lbl.tableaux = nil
+	for _, y := range []int{1, 4} {
+		for x := 0; x < fansPerRow; x++ {
+			t := NewTableau(image.Point{x, y}, FAN_DOWN, MOVE_ONE)
+			t.SetLabel("x") // empty fans are never filled
+			lbl.tableaux = append(lbl.tableaux, t)
+		}
+	}
+}
+
+func (lbl *LaBelleLucie) StartGame() {
+	if lbl.trefoil {
+		MoveNamedCard(lbl.stock, CLUB, 1, lbl.foundations[0])
+		MoveNamedCard(lbl.stock, DIAMOND, 1, lbl.foundations[1])
+		MoveNamedCard(lbl.stock, HEART, 1, lbl.foundations[2])
+		MoveNamedCard(lbl.stock, SPADE, 1, lbl.foundations[3])
+	}
+	for _, pile := range lbl.tableaux {
+		for i := 0; i < 3 && lbl.stock.Len() > 0; i++ {
+			MoveCard(lbl.stock, pile)
+		}
+	}
+	TheBaize.SetRecycles(2)
+}
+
+func (*LaBelleLucie) AfterMove() {
+}
+
+func (*LaBelleLucie) TailMoveError(tail []*Card) (bool, error) {
+	return true, nil
+}
+
+func (*LaBelleLucie) TailAppendError(dst *Pile, tail []*Card) (bool, error) {
+	switch dst.category {
+	case "Foundation":
+		if dst.Empty() {
+			return Compare_Empty(dst, tail[0])
+		} else {
+			return CardPair{dst.Peek(), tail[0]}.Compare_UpSuit()
+		}
+	case "Tableau":
+		if dst.Empty() {
+			return Compare_Empty(dst, tail[0])
+		} else {
+			return CardPair{dst.Peek(), tail[0]}.Compare_DownSuit()
+		}
+	}
+	return true, nil
+}
+
+func (*LaBelleLucie) UnsortedPairs(pile *Pile) int {
+	return UnsortedPairs(pile, CardPair.Compare_DownSuit)
+}
+
+func (*LaBelleLucie) TailTapped(tail []*Card) {
+	tail[0].Owner().vtable.TailTapped(tail)
+}
+
+func (lbl *LaBelleLucie) PileTapped(pile *Pile) {
+	if pile == lbl.stock {
+		Redeal(lbl.stock, lbl.tableaux, lbl.tableaux, 3)
+	}
+}
@@ -0,0 +1,199 @@
+package sol
+
+//lint:file-ignore ST1005 Error messages are toasted, so need to be capitalized
+
+import (
+	"errors"
+	"image"
+
+	"oddstream.games/gosol/util"
+)
+
+// Montana (aka Gaps), Blue Moon and Red Moon;
+// Montana has a grid of 4 x 13 piles and the Aces are removed,
+// the Moons have a grid of 4 x 14 piles with the Aces at the start of each row
+type Montana struct {
+	ScriptBase
+	variant string
+	grid    [][]*Pile // the tableaux, as rows
+}
+
+func (*Montana) Info() *VariantInfo {
+	return &VariantInfo{
+		windowShape: "landscape",
+		wikipedia:   "https://en.wikipedia.org/wiki/Gaps",
+	}
+}
+
+// columns returns the width of the grid
+func (mt *Montana) columns() int {
+	if mt.variant == "montana" {
+		return 13
+	}
+	return 14
+}
+
+// firstOrdinal returns the ordinal of the card that starts each row
+func (mt *Montana) firstOrdinal() int {
+	if mt.variant == "montana" {
+		return 2
+	}
+	return 1
+}
+
+func (mt *Montana) BuildPiles() {
+
+	// the stock is only used to gather and shuffle cards during a redeal,
+	// but is visible so it can show the number of redeals remaining and be tapped
+	mt.stock = NewStock(image.Point{0, 0}, FAN_NONE, 1, 4, nil, 0)
+
+	if mt.variant == "montana" {
+		// somewhere to put the Aces, out of sight
+		mt.discards = []*Pile{NewDiscard(image.Point{-5, -5}, FAN_NONE)}
+	}
+
+	mt.tableaux = nil
+	mt.grid = nil
+	for y := 1; y < 5; y++ {
+		var row []*Pile
+		for x := 0; x < mt.columns(); x++ {
+			t := NewTableau(image.Point{x, y}, FAN_NONE, MOVE_ONE)
+			mt.tableaux = append(mt.tableaux, t)
+			row = append(row, t)
+		}
+		row[0].SetLabel(util.OrdinalToShortString(mt.firstOrdinal()))
+		mt.grid = append(mt.grid, row)
+	}
+}
+
+func (mt *Montana) StartGame() {
+	switch mt.variant {
+	case "montana":
+		for _, pile := range mt.tableaux {
+			MoveCard(mt.stock, pile)
+		}
+		// removing the Aces leaves four gaps
+		for _, pile := range mt.tableaux {
+			if pile.Peek().Ordinal() == 1 {
+				MoveCard(pile, mt.discards[0])
+			}
+		}
+	case "blue moon":
+		for _, row := range mt.grid {
+			for _, pile := range row[1:] {
+				MoveCard(mt.stock, pile)
+			}
+		}
+		// moving the Aces to the start of each row leaves four gaps
+		var y int
+		for _, row := range mt.grid {
+			for _, pile := range row[1:] {
+				if pile.Peek().Ordinal() == 1 {
+					MoveCard(pile, mt.grid[y][0])
+					y++
+				}
+			}
+		}
+	case "red moon":
+		MoveNamedCard(mt.stock, CLUB, 1, mt.grid[0][0])
+		MoveNamedCard(mt.stock, DIAMOND, 1, mt.grid[1][0])
+		MoveNamedCard(mt.stock, HEART, 1, mt.grid[2][0])
+		MoveNamedCard(mt.stock, SPADE, 1, mt.grid[3][0])
+		// leave a gap after each Ace
+		for _, row := range mt.grid {
+			for _, pile := range row[2:] {
+				MoveCard(mt.stock, pile)
+			}
+		}
+	}
+	TheBaize.SetRecycles(2)
+}
+
+func (*Montana) AfterMove() {
+}
+
+func (mt *Montana) TailMoveError(tail []*Card) (bool, error) {
+	if mt.variant != "montana" && tail[0].Ordinal() == 1 {
+		return false, errors.New("Cannot move an Ace")
+	}
+	return true, nil
+}
+
+func (mt *Montana) TailAppendError(dst *Pile, tail []*Card) (bool, error) {
+	switch dst.category {
+	case "Tableau":
+		if !dst.Empty() {
+			return false, errors.New("Can only move a card to a gap")
+		}
+		left := dst.Left()
+		if left == nil {
+			// the first column is labelled with the card that starts a row
+			return Compare_Empty(dst, tail[0])
+		}
+		if left.Empty() {
+			return false, errors.New("Cannot move a card to a gap after another gap")
+		}
+		if left.Peek().Ordinal() == 13 {
+			return false, errors.New("Cannot move a card to a gap after a King")
+		}
+		return CardPair{left.Peek(), tail[0]}.Compare_UpSuit()
+	}
+	return true, nil
+}
+
+func (*Montana) UnsortedPairs(pile *Pile) int {
+	// each pile only holds one card, see PercentComplete
+	return 0
+}
+
+// sortedLen returns the number of piles at the start of a row that are in sequence
+func (mt *Montana) sortedLen(row []*Pile) int {
+	if row[0].Empty() || row[0].Peek().Ordinal() != mt.firstOrdinal() {
+		return 0
+	}
+	var n int = 1
+	for n < len(row) && !row[n].Empty() {
+		if ok, _ := (CardPair{row[n-1].Peek(), row[n].Peek()}).Compare_UpSuit(); !ok {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+func (mt *Montana) Complete() bool {
+	// each row ends with a gap
+	for _, row := range mt.grid {
+		if mt.sortedLen(row) != len(row)-1 {
+			return false
+		}
+	}
+	return true
+}
+
+func (mt *Montana) PercentComplete() int {
+	var sorted int
+	for _, row := range mt.grid {
+		sorted += mt.sortedLen(row)
+	}
+	return sorted * 100 / (len(mt.grid) * (mt.columns() - 1))
+}
+
+func (*Montana) TailTapped(tail []*Card) {
+	tail[0].Owner().vtable.TailTapped(tail)
+}
+
+func (mt *Montana) PileTapped(pile *Pile) {
+	if pile == mt.stock {
+		// gather the cards that are not in sequence, and deal them back leaving a gap after each sequence
+		var gather, deal []*Pile
+		for _, row := range mt.grid {
+			n := mt.sortedLen(row)
+			gather = append(gather, row[n:]...)
+			if n+1 < len(row) {
+				deal = append(deal, row[n+1:]...)
+			}
+		}
+		Redeal(mt.stock, gather, deal, 1)
+	}
+}
@@ -0,0 +1,100 @@
+package sol
+
+//lint:file-ignore ST1005 Error messages are toasted, so need to be capitalized
+
+import (
+	"errors"
+	"image"
+)
+
+// PyramidSolitaire is the Pyramid variant (the pile type is called Pyramid)
+type PyramidSolitaire struct {
+	ScriptBase
+	pyramids []*Pile
+}
+
+func (*PyramidSolitaire) Info() *VariantInfo {
+	return &VariantInfo{
+		windowShape: "landscape",
+		wikipedia:   "https://en.wikipedia.org/wiki/Pyramid_(solitaire)",
+	}
+}
+
+func (py *PyramidSolitaire) BuildPiles() {
+
+	py.stock = NewStock(image.Point{0, 0}, FAN_NONE, 1, 4, nil, 0)
+	py.waste = NewWaste(image.Point{0, 1}, FAN_NONE)
+
+	// rows overlap by half a card, and each row is shifted by half a card
+	py.pyramids = nil
+	for row := 0; row < 7; row++ {
+		for i := 0; i <= row; i++ {
+			x := 1.0 + float64(6-row)*0.5 + float64(i)
+			y := float64(row) * 0.5
+			py.pyramids = append(py.pyramids, NewPyramid(x, y))
+		}
+	}
+
+	py.discards = []*Pile{NewDiscard(image.Point{8, 0}, FAN_NONE)}
+}
+
+func (py *PyramidSolitaire) StartGame() {
+	for _, pile := range py.pyramids {
+		MoveCard(py.stock, pile)
+	}
+	TheBaize.SetRecycles(2)
+	MoveCard(py.stock, py.waste)
+}
+
+func (*PyramidSolitaire) AfterMove() {
+}
+
+// Complete is true when the pyramid has been cleared, whatever is left in the stock and waste
+func (py *PyramidSolitaire) Complete() bool {
+	return pilesCleared(py.pyramids)
+}
+
+func (py *PyramidSolitaire) PercentComplete() int {
+	return percentCleared(py.pyramids, len(py.pyramids))
+}
+
+func (*PyramidSolitaire) TailMoveError(tail []*Card) (bool, error) {
+	return true, nil
+}
+
+func (*PyramidSolitaire) TailAppendError(dst *Pile, tail []*Card) (bool, error) {
+	return true, nil
+}
+
+func (*PyramidSolitaire) UnsortedPairs(pile *Pile) int {
+	return 0
+}
+
+func (*PyramidSolitaire) PairError(c1, c2 *Card) (bool, error) {
+	if c1.Ordinal()+c2.Ordinal() != 13 {
+		return false, errors.New("The two cards must add up to 13")
+	}
+	return true, nil
+}
+
+func (*PyramidSolitaire) SingleError(c *Card) (bool, error) {
+	if c.Ordinal() != 13 {
+		return false, errors.New("Only a King can be removed by itself")
+	}
+	return true, nil
+}
+
+func (py *PyramidSolitaire) TailTapped(tail []*Card) {
+	var pile *Pile = tail[0].Owner()
+	if pile == py.stock && len(tail) == 1 {
+		MoveCard(py.stock, py.waste)
+	} else {
+		TheBaize.TapPair(py, tail[0])
+	}
+}
+
+func (py *PyramidSolitaire) PileTapped(pile *Pile) {
+	if pile == py.stock {
+		RecycleWasteToStock(py.waste, py.stock)
+	}
+}
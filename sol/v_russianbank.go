@@ -0,0 +1,186 @@
+package sol
+
+//lint:file-ignore ST1005 Error messages are toasted, so need to be capitalized
+
+import (
+	"errors"
+	"image"
+)
+
+// RussianBank (aka Crapette) is a two-player game; each player has a hand (Stock),
+// Waste and Reserve, and they share the foundations and tableaux.
+// A player can also get rid of cards by loading them onto the other player's Waste or Reserve.
+type RussianBank struct {
+	ScriptBase
+	playerStock, playerWaste, playerReserve [3]*Pile // indexed by player (1 or 2)
+}
+
+func (*RussianBank) Info() *VariantInfo {
+	return &VariantInfo{
+		windowShape: "landscape",
+		wikipedia:   "https://en.wikipedia.org/wiki/Russian_Bank",
+	}
+}
+
+func (rb *RussianBank) BuildPiles() {
+
+	// the main stock holds both packs, and is player 1's hand
+	rb.stock = NewStock(image.Point{0, 5}, FAN_NONE, 2, 4, nil, 0)
+	rb.stock.SetPlayer(1)
+	rb.playerStock[1] = rb.stock
+	rb.playerWaste[1] = NewWaste(image.Point{1, 5}, FAN_NONE)
+	rb.playerReserve[1] = NewReserve(image.Point{3, 5}, FAN_NONE)
+
+	// player 2 sits opposite player 1
+	rb.playerStock[2] = NewPlayerStock(image.Point{7, 0}, 2)
+	rb.playerWaste[2] = NewWaste(image.Point{6, 0}, FAN_NONE)
+	rb.playerReserve[2] = NewReserve(image.Point{4, 0}, FAN_NONE)
+
+	for player := 1; player <= 2; player++ {
+		rb.playerWaste[player].SetPlayer(player)
+		rb.playerReserve[player].SetPlayer(player)
+	}
+	rb.waste = rb.playerWaste[1]
+	rb.reserves = []*Pile{rb.playerReserve[1], rb.playerReserve[2]}
+
+	rb.foundations = nil
+	for x := 0; x < 8; x++ {
+		f := NewFoundation(image.Point{x, 1})
+		rb.foundations = append(rb.foundations, f)
+		f.SetLabel("A")
+	}
+
+	rb.tableaux = nil
+	for x := 0; x < 8; x++ {
+		rb.tableaux = append(rb.tableaux, NewTableau(image.Point{x, 2}, FAN_DOWN, MOVE_ONE))
+	}
+}
+
+func (rb *RussianBank) StartGame() {
+	// each player gets a pack (of sorts)
+	for i := 0; i < 52; i++ {
+		MoveCard(rb.stock, rb.playerStock[2])
+	}
+	for player := 1; player <= 2; player++ {
+		for i := 0; i < 12; i++ {
+			MoveCard(rb.playerStock[player], rb.playerReserve[player]).FlipDown()
+		}
+		rb.playerReserve[player].Peek().FlipUp()
+		// player 1 deals to the left hand tableaux, player 2 to the right
+		for _, pile := range rb.tableaux[(player-1)*4 : player*4] {
+			MoveCard(rb.playerStock[player], pile)
+		}
+	}
+	// an empty hand can always be refilled from the waste
+	TheBaize.SetRecycles(32767)
+	rb.playerStock[2].SetRune(RECYCLE_RUNE)
+	rb.showHand()
+}
+
+// showHand turns up the top card of the hand of the player to move, and turns down the other
+func (rb *RussianBank) showHand() {
+	for player := 1; player <= 2; player++ {
+		if card := rb.playerStock[player].Peek(); card != nil {
+			if player == TheBaize.Turn() {
+				card.FlipUp()
+			} else {
+				card.FlipDown()
+			}
+		}
+	}
+}
+
+func (rb *RussianBank) AfterMove() {
+	// a turn ends when the player moves a card from their hand to their waste
+	waste := rb.playerWaste[TheBaize.Turn()]
+	if waste.Len() > TheBaize.LenBeforeMove(waste) {
+		TheBaize.EndTurn()
+	}
+	rb.showHand()
+}
+
+func (*RussianBank) TailMoveError(tail []*Card) (bool, error) {
+	return true, nil
+}
+
+func (*RussianBank) TailAppendError(dst *Pile, tail []*Card) (bool, error) {
+	switch dst.category {
+	case "Foundation":
+		if dst.Empty() {
+			return Compare_Empty(dst, tail[0])
+		} else {
+			return CardPair{dst.Peek(), tail[0]}.Compare_UpSuit()
+		}
+	case "Tableau":
+		if dst.Empty() {
+			return true, nil
+		} else {
+			return CardPair{dst.Peek(), tail[0]}.Compare_DownAltColor()
+		}
+	}
+	return true, nil
+}
+
+// LoadError allows a card onto the other player's Waste or Reserve if it is the same suit as,
+// and one higher or lower than, the card showing there
+func (*RussianBank) LoadError(dst *Pile, card *Card) (bool, error) {
+	if dst.Empty() || dst.Peek().Prone() {
+		return false, errors.New("Can only load a card onto a face up card")
+	}
+	if card.Prone() {
+		return false, errors.New("Cannot load a face down card")
+	}
+	if ok, _ := (CardPair{dst.Peek(), card}).Compare_UpSuit(); ok {
+		return true, nil
+	}
+	if ok, _ := (CardPair{dst.Peek(), card}).Compare_DownSuit(); ok {
+		return true, nil
+	}
+	return false, errors.New("A loaded card must be the same suit, and one higher or lower")
+}
+
+func (*RussianBank) UnsortedPairs(pile *Pile) int {
+	return UnsortedPairs(pile, CardPair.Compare_DownAltColor)
+}
+
+func (rb *RussianBank) TailTapped(tail []*Card) {
+	var pile *Pile = tail[0].Owner()
+	if pile.IsStock() {
+		// tapping the hand plays it's top card to the waste, ending the turn
+		if ok, err := pile.CanMoveTail(tail); !ok {
+			TheUI.Toast(err.Error())
+		} else {
+			MoveCard(pile, rb.playerWaste[pile.Player()])
+		}
+	} else {
+		pile.vtable.TailTapped(tail)
+	}
+}
+
+func (rb *RussianBank) PileTapped(pile *Pile) {
+	// when a player's hand is empty, their waste is turned over to make a new hand
+	if pile.IsStock() && pile.Player() == TheBaize.Turn() {
+		waste := rb.playerWaste[pile.Player()]
+		for waste.Len() > 0 {
+			MoveCard(waste, pile)
+		}
+	}
+}
+
+func (rb *RussianBank) Winner() int {
+	for player := 1; player <= 2; player++ {
+		if rb.playerStock[player].Empty() && rb.playerWaste[player].Empty() && rb.playerReserve[player].Empty() {
+			return player
+		}
+	}
+	return 0
+}
+
+func (rb *RussianBank) BotEndTurn() {
+	var stock *Pile = rb.playerStock[2]
+	if stock.Empty() {
+		rb.PileTapped(stock)
+	} else {
+		MoveCard(stock, rb.playerWaste[2])
+	}
+}
@@ -0,0 +1,208 @@
+package sol
+
+//lint:file-ignore ST1005 Error messages are toasted, so need to be capitalized
+
+import (
+	"errors"
+	"image"
+	"math/rand"
+)
+
+// SpiteAndMalice is a two-player game; each player has a pay-off pile (Reserve),
+// a hand of five cards (each in it's own Reserve) and four discard piles (Tableau),
+// and they share the Stock and the center (Foundation) piles.
+type SpiteAndMalice struct {
+	ScriptBase
+	payoff       [3]*Pile   // indexed by player (1 or 2)
+	hand         [3][]*Pile // indexed by player (1 or 2)
+	discardPiles [3][]*Pile // indexed by player (1 or 2)
+}
+
+func (*SpiteAndMalice) Info() *VariantInfo {
+	return &VariantInfo{
+		windowShape: "square",
+		wikipedia:   "https://en.wikipedia.org/wiki/Spite_and_Malice",
+	}
+}
+
+func (sm *SpiteAndMalice) BuildPiles() {
+
+	sm.stock = NewStock(image.Point{0, 3}, FAN_NONE, 2, 4, nil, 0)
+
+	// player 2 sits at the top, player 1 at the bottom;
+	// pay-off piles are made first, because the bot looks at piles in order
+	var rows = [3]struct{ payoff, discard int }{{}, {payoff: 6, discard: 4}, {payoff: 0, discard: 1}}
+	sm.reserves = nil
+	for player := 1; player <= 2; player++ {
+		sm.payoff[player] = NewReserve(image.Point{0, rows[player].payoff}, FAN_NONE)
+		sm.payoff[player].SetPlayer(player)
+		sm.reserves = append(sm.reserves, sm.payoff[player])
+	}
+	for player := 1; player <= 2; player++ {
+		sm.hand[player] = nil
+		for x := 2; x < 7; x++ {
+			r := NewReserve(image.Point{x, rows[player].payoff}, FAN_NONE)
+			r.SetPlayer(player)
+			sm.hand[player] = append(sm.hand[player], r)
+			sm.reserves = append(sm.reserves, r)
+		}
+	}
+
+	sm.foundations = nil
+	for x := 2; x < 6; x++ {
+		sm.foundations = append(sm.foundations, NewFoundation(image.Point{x, 3}))
+	}
+
+	sm.tableaux = nil
+	for player := 1; player <= 2; player++ {
+		sm.discardPiles[player] = nil
+		for x := 2; x < 6; x++ {
+			t := NewTableau(image.Point{x, rows[player].discard}, FAN_DOWN3, MOVE_ONE)
+			t.SetPlayer(player)
+			sm.discardPiles[player] = append(sm.discardPiles[player], t)
+			sm.tableaux = append(sm.tableaux, t)
+		}
+	}
+}
+
+func (sm *SpiteAndMalice) StartGame() {
+	for player := 1; player <= 2; player++ {
+		for i := 0; i < 20; i++ {
+			MoveCard(sm.stock, sm.payoff[player]).FlipDown()
+		}
+		sm.payoff[player].Peek().FlipUp()
+	}
+	TheBaize.SetRecycles(0)
+	sm.fillHand()
+}
+
+// fillHand tops up the hand of the player to move to five cards, and hides the other player's hand
+func (sm *SpiteAndMalice) fillHand() {
+	for player := 1; player <= 2; player++ {
+		for _, pile := range sm.hand[player] {
+			if player == TheBaize.Turn() {
+				if pile.Empty() {
+					MoveCard(sm.stock, pile)
+				}
+				if card := pile.Peek(); card != nil {
+					card.FlipUp()
+				}
+			} else if card := pile.Peek(); card != nil {
+				card.FlipDown()
+			}
+		}
+	}
+}
+
+func (sm *SpiteAndMalice) handEmpty(player int) bool {
+	for _, pile := range sm.hand[player] {
+		if !pile.Empty() {
+			return false
+		}
+	}
+	return true
+}
+
+func (sm *SpiteAndMalice) AfterMove() {
+	// a turn ends when the player moves a card from their hand to one of their discard piles,
+	// and the next player starts by filling their hand
+	var newTurn bool
+	for _, pile := range sm.discardPiles[TheBaize.Turn()] {
+		if pile.Len() > TheBaize.LenBeforeMove(pile) {
+			TheBaize.EndTurn()
+			newTurn = true
+			break
+		}
+	}
+
+	// a completed center pile (Ace to Queen) is shuffled back into the stock
+	for _, pile := range sm.foundations {
+		if pile.Len() == 12 {
+			for pile.Len() > 0 {
+				MoveCard(pile, sm.stock)
+			}
+			rng := rand.New(rand.NewSource(TheBaize.seed + int64(sm.stock.Len())))
+			rng.Shuffle(sm.stock.Len(), sm.stock.Swap)
+		}
+	}
+
+	// a player who empties their hand during their turn draws another five cards
+	if newTurn || sm.handEmpty(TheBaize.Turn()) {
+		sm.fillHand()
+	} else {
+		for _, pile := range sm.hand[3-TheBaize.Turn()] {
+			if card := pile.Peek(); card != nil {
+				card.FlipDown()
+			}
+		}
+	}
+}
+
+func (sm *SpiteAndMalice) TailMoveError(tail []*Card) (bool, error) {
+	if tail[0].Owner() == sm.stock {
+		return false, errors.New("Cards are only dealt from the Stock to a hand")
+	}
+	return true, nil
+}
+
+func (sm *SpiteAndMalice) TailAppendError(dst *Pile, tail []*Card) (bool, error) {
+	var card *Card = tail[0]
+	switch dst.category {
+	case "Foundation":
+		// build up from Ace to Queen, regardless of suit; Kings are wild
+		if card.Ordinal() == 13 || card.Ordinal() == dst.Len()+1 {
+			return true, nil
+		}
+		if dst.Empty() {
+			return false, errors.New("A center pile must start with an Ace (or a King)")
+		}
+		return false, errors.New("Center piles are built up in sequence, regardless of suit (Kings are wild)")
+	case "Tableau":
+		if dst.Player() != TheBaize.Turn() {
+			return false, errors.New("Cannot move a card to the other player's discard piles")
+		}
+		for _, pile := range sm.hand[TheBaize.Turn()] {
+			if card.Owner() == pile {
+				return true, nil
+			}
+		}
+		return false, errors.New("Only cards from your hand can be discarded")
+	}
+	return true, nil
+}
+
+func (*SpiteAndMalice) UnsortedPairs(pile *Pile) int {
+	return 0
+}
+
+func (*SpiteAndMalice) TailTapped(tail []*Card) {
+	tail[0].Owner().vtable.TailTapped(tail)
+}
+
+func (*SpiteAndMalice) PileTapped(*Pile) {
+}
+
+func (sm *SpiteAndMalice) Winner() int {
+	for player := 1; player <= 2; player++ {
+		if sm.payoff[player].Empty() {
+			return player
+		}
+	}
+	return 0
+}
+
+func (sm *SpiteAndMalice) BotEndTurn() {
+	// discard a card from the hand onto the shortest discard pile
+	var dst *Pile = sm.discardPiles[2][0]
+	for _, pile := range sm.discardPiles[2] {
+		if pile.Len() < dst.Len() {
+			dst = pile
+		}
+	}
+	for _, pile := range sm.hand[2] {
+		if !pile.Empty() {
+			MoveCard(pile, dst)
+			return
+		}
+	}
+}
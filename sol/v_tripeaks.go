@@ -0,0 +1,112 @@
+package sol
+
+//lint:file-ignore ST1005 Error messages are toasted, so need to be capitalized
+
+import (
+	"errors"
+	"image"
+)
+
+type TriPeaks struct {
+	ScriptBase
+	peaks []*Pile
+}
+
+func (*TriPeaks) Info() *VariantInfo {
+	return &VariantInfo{
+		windowShape: "landscape",
+		wikipedia:   "https://en.wikipedia.org/wiki/Tri_Peaks_(game)",
+	}
+}
+
+func (tp *TriPeaks) BuildPiles() {
+
+	tp.stock = NewStock(image.Point{4, 3}, FAN_NONE, 1, 4, nil, 0)
+
+	// three overlapping peaks, built from the top down, so that lower rows cover upper rows
+	var rows = [][]float64{
+		{1.5, 4.5, 7.5},
+		{1, 2, 4, 5, 7, 8},
+		{0.5, 1.5, 2.5, 3.5, 4.5, 5.5, 6.5, 7.5, 8.5},
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+	}
+	tp.peaks = nil
+	for y, row := range rows {
+		for _, x := range row {
+			tp.peaks = append(tp.peaks, NewPyramid(x, float64(y)*0.5))
+		}
+	}
+
+	tp.foundations = []*Pile{NewFoundation(image.Point{5, 3})}
+}
+
+func (tp *TriPeaks) StartGame() {
+	for _, pile := range tp.peaks {
+		MoveCard(tp.stock, pile).FlipDown()
+	}
+	tp.flipExposedPeaks()
+	TheBaize.SetRecycles(0)
+	MoveCard(tp.stock, tp.foundations[0])
+}
+
+// flipExposedPeaks turns face up any cards that are no longer covered
+func (tp *TriPeaks) flipExposedPeaks() {
+	for _, pile := range tp.peaks {
+		if !pile.Empty() && !pile.Blocked() {
+			pile.Peek().FlipUp()
+		}
+	}
+}
+
+func (tp *TriPeaks) AfterMove() {
+	tp.flipExposedPeaks()
+}
+
+// Complete is true when the peaks have been cleared, whatever is left in the stock
+func (tp *TriPeaks) Complete() bool {
+	return pilesCleared(tp.peaks)
+}
+
+func (tp *TriPeaks) PercentComplete() int {
+	return percentCleared(tp.peaks, len(tp.peaks))
+}
+
+func (*TriPeaks) TailMoveError(tail []*Card) (bool, error) {
+	return true, nil
+}
+
+func (*TriPeaks) TailAppendError(dst *Pile, tail []*Card) (bool, error) {
+	switch dst.category {
+	case "Foundation":
+		if tail[0].Owner().IsStock() {
+			return true, nil
+		}
+		if dst.Empty() {
+			return true, nil
+		}
+		// Kings and Aces are adjacent
+		c1, c2 := dst.Peek().Ordinal(), tail[0].Ordinal()
+		if c1-c2 == 1 || c2-c1 == 1 || c1-c2 == 12 || c2-c1 == 12 {
+			return true, nil
+		}
+		return false, errors.New("The card must be one higher or one lower than the Foundation card")
+	}
+	return true, nil
+}
+
+func (*TriPeaks) UnsortedPairs(pile *Pile) int {
+	return 0
+}
+
+func (tp *TriPeaks) TailTapped(tail []*Card) {
+	var pile *Pile = tail[0].Owner()
+	if pile == tp.stock && len(tail) == 1 {
+		MoveCard(tp.stock, tp.foundations[0])
+	} else {
+		pile.vtable.TailTapped(tail)
+	}
+}
+
+func (*TriPeaks) PileTapped(*Pile) {
+	// no recycles
+}
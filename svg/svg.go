@@ -0,0 +1,697 @@
+// Package svg draws simple SVG images, such as card faces, with fogleman/gg.
+//
+// Only the common subset is understood: the basic shapes, paths (including arcs),
+// groups, transforms, and solid fill and stroke colors with opacity. Anything else
+// (gradients, patterns, text, clipping, masks, <use>) is skipped.
+package svg
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/fogleman/gg"
+)
+
+// matrix is an affine transform [a c e; b d f]
+type matrix struct {
+	a, b, c, d, e, f float64
+}
+
+var identity = matrix{a: 1, d: 1}
+
+// mul returns the transform that applies n, then m
+func (m matrix) mul(n matrix) matrix {
+	return matrix{
+		a: m.a*n.a + m.c*n.b,
+		b: m.b*n.a + m.d*n.b,
+		c: m.a*n.c + m.c*n.d,
+		d: m.b*n.c + m.d*n.d,
+		e: m.a*n.e + m.c*n.f + m.e,
+		f: m.b*n.e + m.d*n.f + m.f,
+	}
+}
+
+func (m matrix) apply(x, y float64) (float64, float64) {
+	return m.a*x + m.c*y + m.e, m.b*x + m.d*y + m.f
+}
+
+// scale is roughly how much the transform enlarges things, for stroke widths
+func (m matrix) scale() float64 {
+	return math.Sqrt(math.Abs(m.a*m.d - m.b*m.c))
+}
+
+// style is the inherited painting state
+type style struct {
+	fill, stroke                        color.Color // nil means none
+	fillOpacity, strokeOpacity, opacity float64
+	strokeWidth                         float64
+	evenOdd                             bool
+}
+
+// op is a path segment, with points already in user space
+type op struct {
+	cmd byte // M, L, C, Q or Z
+	pts []float64
+}
+
+// shape is something to draw
+type shape struct {
+	ops   []op
+	m     matrix
+	style style
+}
+
+// Document is a parsed SVG image
+type Document struct {
+	minX, minY, width, height float64
+	shapes                    []shape
+}
+
+// Size returns the size of the image, in its own units
+func (doc *Document) Size() (float64, float64) {
+	return doc.width, doc.height
+}
+
+// skipped elements have nothing drawable, or only things drawn by reference, which aren't supported
+var skipped = map[string]bool{
+	"defs": true, "clipPath": true, "mask": true, "symbol": true, "pattern": true, "marker": true,
+	"linearGradient": true, "radialGradient": true, "filter": true, "metadata": true, "title": true, "desc": true,
+	"style": true, "script": true, "text": true,
+}
+
+// Parse reads an SVG image
+func Parse(r io.Reader) (*Document, error) {
+	dec := xml.NewDecoder(r)
+	doc := &Document{}
+	type frame struct {
+		m     matrix
+		style style
+	}
+	stack := []frame{{m: identity, style: style{fill: color.Black, fillOpacity: 1, strokeOpacity: 1, opacity: 1, strokeWidth: 1}}}
+	skipDepth := 0
+	sawRoot := false
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if skipDepth > 0 || skipped[t.Name.Local] {
+				skipDepth++
+				continue
+			}
+			attrs := map[string]string{}
+			for _, a := range t.Attr {
+				attrs[a.Name.Local] = a.Value
+			}
+			top := stack[len(stack)-1]
+			f := frame{m: top.m, style: top.style}
+			if tr, ok := attrs["transform"]; ok {
+				f.m = f.m.mul(parseTransform(tr))
+			}
+			applyStyle(&f.style, attrs)
+			stack = append(stack, f)
+
+			switch t.Name.Local {
+			case "svg":
+				if !sawRoot {
+					sawRoot = true
+					if err := doc.setViewport(attrs); err != nil {
+						return nil, err
+					}
+				}
+			case "path":
+				ops, err := parsePath(attrs["d"])
+				if err != nil {
+					return nil, err
+				}
+				doc.add(ops, f.m, f.style)
+			case "rect":
+				doc.add(rectOps(num(attrs, "x"), num(attrs, "y"), num(attrs, "width"), num(attrs, "height"), num(attrs, "rx"), num(attrs, "ry")), f.m, f.style)
+			case "circle":
+				r := num(attrs, "r")
+				doc.add(ellipseOps(num(attrs, "cx"), num(attrs, "cy"), r, r), f.m, f.style)
+			case "ellipse":
+				doc.add(ellipseOps(num(attrs, "cx"), num(attrs, "cy"), num(attrs, "rx"), num(attrs, "ry")), f.m, f.style)
+			case "line":
+				ops := []op{{'M', []float64{num(attrs, "x1"), num(attrs, "y1")}}, {'L', []float64{num(attrs, "x2"), num(attrs, "y2")}}}
+				s := f.style
+				s.fill = nil
+				doc.add(ops, f.m, s)
+			case "polyline", "polygon":
+				pts := parseNumbers(attrs["points"])
+				var ops []op
+				for i := 0; i+1 < len(pts); i += 2 {
+					cmd := byte('L')
+					if i == 0 {
+						cmd = 'M'
+					}
+					ops = append(ops, op{cmd, pts[i : i+2]})
+				}
+				if t.Name.Local == "polygon" && len(ops) > 0 {
+					ops = append(ops, op{cmd: 'Z'})
+				}
+				doc.add(ops, f.m, f.style)
+			}
+		case xml.EndElement:
+			if skipDepth > 0 {
+				skipDepth--
+				continue
+			}
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+	if !sawRoot {
+		return nil, errors.New("not an SVG image")
+	}
+	return doc, nil
+}
+
+func (doc *Document) add(ops []op, m matrix, s style) {
+	if len(ops) > 0 && (s.fill != nil || s.stroke != nil) {
+		doc.shapes = append(doc.shapes, shape{ops: ops, m: m, style: s})
+	}
+}
+
+// setViewport reads the size of the image from the root element
+func (doc *Document) setViewport(attrs map[string]string) error {
+	if vb := parseNumbers(attrs["viewBox"]); len(vb) == 4 {
+		doc.minX, doc.minY, doc.width, doc.height = vb[0], vb[1], vb[2], vb[3]
+	} else {
+		doc.width, doc.height = num(attrs, "width"), num(attrs, "height")
+	}
+	if doc.width <= 0 || doc.height <= 0 {
+		return errors.New("SVG image has no size")
+	}
+	return nil
+}
+
+// Render draws the image stretched to width by height pixels
+func (doc *Document) Render(width, height int) image.Image {
+	dc := gg.NewContext(width, height)
+	view := matrix{a: float64(width) / doc.width, d: float64(height) / doc.height}.mul(matrix{a: 1, d: 1, e: -doc.minX, f: -doc.minY})
+	for _, s := range doc.shapes {
+		m := view.mul(s.m)
+		for _, o := range s.ops {
+			switch o.cmd {
+			case 'M':
+				dc.MoveTo(m.apply(o.pts[0], o.pts[1]))
+			case 'L':
+				dc.LineTo(m.apply(o.pts[0], o.pts[1]))
+			case 'Q':
+				x1, y1 := m.apply(o.pts[0], o.pts[1])
+				x, y := m.apply(o.pts[2], o.pts[3])
+				dc.QuadraticTo(x1, y1, x, y)
+			case 'C':
+				x1, y1 := m.apply(o.pts[0], o.pts[1])
+				x2, y2 := m.apply(o.pts[2], o.pts[3])
+				x, y := m.apply(o.pts[4], o.pts[5])
+				dc.CubicTo(x1, y1, x2, y2, x, y)
+			case 'Z':
+				dc.ClosePath()
+			}
+		}
+		if s.style.fill != nil {
+			if s.style.evenOdd {
+				dc.SetFillRule(gg.FillRuleEvenOdd)
+			} else {
+				dc.SetFillRule(gg.FillRuleWinding)
+			}
+			dc.SetColor(withOpacity(s.style.fill, s.style.fillOpacity*s.style.opacity))
+			dc.FillPreserve()
+		}
+		if s.style.stroke != nil && s.style.strokeWidth > 0 {
+			dc.SetColor(withOpacity(s.style.stroke, s.style.strokeOpacity*s.style.opacity))
+			dc.SetLineWidth(s.style.strokeWidth * m.scale())
+			dc.StrokePreserve()
+		}
+		dc.ClearPath()
+	}
+	return dc.Image()
+}
+
+func withOpacity(c color.Color, opacity float64) color.Color {
+	// RGBA gives premultiplied values, so every channel is scaled
+	r, g, b, a := c.RGBA()
+	o := math.Max(0, math.Min(1, opacity))
+	return color.RGBA64{R: uint16(float64(r) * o), G: uint16(float64(g) * o), B: uint16(float64(b) * o), A: uint16(float64(a) * o)}
+}
+
+// num reads a length attribute, ignoring any units
+func num(attrs map[string]string, name string) float64 {
+	v := strings.TrimSpace(attrs[name])
+	v = strings.TrimRight(v, "abcdefghijklmnopqrstuvwxyz%")
+	f, _ := strconv.ParseFloat(v, 64)
+	return f
+}
+
+// parseNumbers reads a list of numbers separated by commas and/or spaces
+func parseNumbers(s string) []float64 {
+	var nums []float64
+	p := pathScanner{s: s}
+	for {
+		f, ok := p.number()
+		if !ok {
+			return nums
+		}
+		nums = append(nums, f)
+	}
+}
+
+// applyStyle updates s from presentation attributes, then from the style attribute, which takes precedence
+func applyStyle(s *style, attrs map[string]string) {
+	props := map[string]string{}
+	for _, name := range []string{"fill", "stroke", "stroke-width", "opacity", "fill-opacity", "stroke-opacity", "fill-rule"} {
+		if v, ok := attrs[name]; ok {
+			props[name] = v
+		}
+	}
+	for _, decl := range strings.Split(attrs["style"], ";") {
+		if k, v, ok := strings.Cut(decl, ":"); ok {
+			props[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	}
+	for k, v := range props {
+		switch k {
+		case "fill":
+			s.fill = parseColor(v)
+		case "stroke":
+			s.stroke = parseColor(v)
+		case "stroke-width":
+			s.strokeWidth = num(map[string]string{k: v}, k)
+		case "opacity":
+			s.opacity *= parseOpacity(v)
+		case "fill-opacity":
+			s.fillOpacity = parseOpacity(v)
+		case "stroke-opacity":
+			s.strokeOpacity = parseOpacity(v)
+		case "fill-rule":
+			s.evenOdd = v == "evenodd"
+		}
+	}
+}
+
+func parseOpacity(v string) float64 {
+	if strings.HasSuffix(v, "%") {
+		f, err := strconv.ParseFloat(strings.TrimSuffix(v, "%"), 64)
+		if err != nil {
+			return 1
+		}
+		return f / 100
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 1
+	}
+	return f
+}
+
+var namedColors = map[string]color.Color{
+	"black": color.Black, "white": color.White,
+	"red": color.RGBA{255, 0, 0, 255}, "green": color.RGBA{0, 128, 0, 255}, "blue": color.RGBA{0, 0, 255, 255},
+	"yellow": color.RGBA{255, 255, 0, 255}, "gold": color.RGBA{255, 215, 0, 255}, "orange": color.RGBA{255, 165, 0, 255},
+	"gray": color.RGBA{128, 128, 128, 255}, "grey": color.RGBA{128, 128, 128, 255}, "silver": color.RGBA{192, 192, 192, 255},
+	"maroon": color.RGBA{128, 0, 0, 255}, "navy": color.RGBA{0, 0, 128, 255}, "purple": color.RGBA{128, 0, 128, 255},
+	"crimson": color.RGBA{220, 20, 60, 255}, "darkred": color.RGBA{139, 0, 0, 255}, "brown": color.RGBA{165, 42, 42, 255},
+}
+
+// parseColor reads a color, returning nil for none (or for paint servers such as gradients, which aren't supported)
+func parseColor(v string) color.Color {
+	v = strings.ToLower(strings.TrimSpace(v))
+	switch {
+	case v == "none" || v == "transparent" || strings.HasPrefix(v, "url("):
+		return nil
+	case strings.HasPrefix(v, "#"):
+		hex := v[1:]
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		n, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil || len(hex) != 6 {
+			return color.Black
+		}
+		return color.RGBA{uint8(n >> 16), uint8(n >> 8), uint8(n), 255}
+	case strings.HasPrefix(v, "rgb(") && strings.HasSuffix(v, ")"):
+		var rgb [3]uint8
+		for i, part := range strings.SplitN(v[4:len(v)-1], ",", 3) {
+			part = strings.TrimSpace(part)
+			f, _ := strconv.ParseFloat(strings.TrimSuffix(part, "%"), 64)
+			if strings.HasSuffix(part, "%") {
+				f = f * 255 / 100
+			}
+			rgb[i] = uint8(math.Max(0, math.Min(255, f)))
+		}
+		return color.RGBA{rgb[0], rgb[1], rgb[2], 255}
+	}
+	if c, ok := namedColors[v]; ok {
+		return c
+	}
+	return color.Black
+}
+
+// parseTransform reads a list of transforms, eg "translate(10,20) rotate(45)"
+func parseTransform(s string) matrix {
+	m := identity
+	for {
+		open := strings.IndexByte(s, '(')
+		close := strings.IndexByte(s, ')')
+		if open < 0 || close < open {
+			return m
+		}
+		name := strings.Trim(strings.TrimSpace(s[:open]), ",")
+		args := parseNumbers(s[open+1 : close])
+		s = s[close+1:]
+		arg := func(i int, def float64) float64 {
+			if i < len(args) {
+				return args[i]
+			}
+			return def
+		}
+		var t matrix
+		switch name {
+		case "matrix":
+			t = matrix{arg(0, 1), arg(1, 0), arg(2, 0), arg(3, 1), arg(4, 0), arg(5, 0)}
+		case "translate":
+			t = matrix{a: 1, d: 1, e: arg(0, 0), f: arg(1, 0)}
+		case "scale":
+			sx := arg(0, 1)
+			t = matrix{a: sx, d: arg(1, sx)}
+		case "rotate":
+			r := arg(0, 0) * math.Pi / 180
+			cx, cy := arg(1, 0), arg(2, 0)
+			t = matrix{a: 1, d: 1, e: cx, f: cy}.
+				mul(matrix{a: math.Cos(r), b: math.Sin(r), c: -math.Sin(r), d: math.Cos(r)}).
+				mul(matrix{a: 1, d: 1, e: -cx, f: -cy})
+		case "skewX":
+			t = matrix{a: 1, c: math.Tan(arg(0, 0) * math.Pi / 180), d: 1}
+		case "skewY":
+			t = matrix{a: 1, b: math.Tan(arg(0, 0) * math.Pi / 180), d: 1}
+		default:
+			continue
+		}
+		m = m.mul(t)
+	}
+}
+
+// pathScanner reads the numbers and commands of path data
+type pathScanner struct {
+	s string
+	i int
+}
+
+func (p *pathScanner) skipSeparators() {
+	for p.i < len(p.s) && (p.s[p.i] == ' ' || p.s[p.i] == ',' || p.s[p.i] == '\t' || p.s[p.i] == '\n' || p.s[p.i] == '\r') {
+		p.i++
+	}
+}
+
+// number reads the next number, if there is one
+func (p *pathScanner) number() (float64, bool) {
+	p.skipSeparators()
+	start := p.i
+	if p.i < len(p.s) && (p.s[p.i] == '+' || p.s[p.i] == '-') {
+		p.i++
+	}
+	sawDot, sawDigit := false, false
+	for p.i < len(p.s) {
+		c := p.s[p.i]
+		if c >= '0' && c <= '9' {
+			sawDigit = true
+		} else if c == '.' && !sawDot {
+			sawDot = true
+		} else {
+			break
+		}
+		p.i++
+	}
+	if !sawDigit {
+		p.i = start
+		return 0, false
+	}
+	if p.i < len(p.s) && (p.s[p.i] == 'e' || p.s[p.i] == 'E') {
+		j := p.i + 1
+		if j < len(p.s) && (p.s[j] == '+' || p.s[j] == '-') {
+			j++
+		}
+		if j < len(p.s) && p.s[j] >= '0' && p.s[j] <= '9' {
+			for j < len(p.s) && p.s[j] >= '0' && p.s[j] <= '9' {
+				j++
+			}
+			p.i = j
+		}
+	}
+	f, err := strconv.ParseFloat(p.s[start:p.i], 64)
+	return f, err == nil
+}
+
+// flag reads an arc flag, which may not be separated from what follows
+func (p *pathScanner) flag() (bool, bool) {
+	p.skipSeparators()
+	if p.i < len(p.s) && (p.s[p.i] == '0' || p.s[p.i] == '1') {
+		p.i++
+		return p.s[p.i-1] == '1', true
+	}
+	return false, false
+}
+
+// parsePath turns path data into absolute moves, lines, curves and closes
+func parsePath(d string) ([]op, error) {
+	var ops []op
+	p := pathScanner{s: d}
+	var x, y, startX, startY float64
+	var lastCtrlX, lastCtrlY float64
+	var cmd, lastCmd byte
+	for {
+		p.skipSeparators()
+		if p.i >= len(p.s) {
+			return ops, nil
+		}
+		if c := p.s[p.i]; (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+			cmd = c
+			p.i++
+		} else if cmd == 0 {
+			return nil, fmt.Errorf("path data must start with a command: %q", d)
+		}
+		// read n numbers for the current command
+		args := func(n int) ([]float64, bool) {
+			a := make([]float64, n)
+			for i := range a {
+				f, ok := p.number()
+				if !ok {
+					return nil, false
+				}
+				a[i] = f
+			}
+			return a, true
+		}
+		rel := cmd >= 'a'
+		ox, oy := 0.0, 0.0
+		if rel {
+			ox, oy = x, y
+		}
+		ok := true
+		var a []float64
+		switch cmd | 0x20 { // lower case
+		case 'm':
+			if a, ok = args(2); ok {
+				x, y = ox+a[0], oy+a[1]
+				startX, startY = x, y
+				ops = append(ops, op{'M', []float64{x, y}})
+				// further pairs are lines
+				if rel {
+					cmd = 'l'
+				} else {
+					cmd = 'L'
+				}
+			}
+		case 'l':
+			if a, ok = args(2); ok {
+				x, y = ox+a[0], oy+a[1]
+				ops = append(ops, op{'L', []float64{x, y}})
+			}
+		case 'h':
+			if a, ok = args(1); ok {
+				x = ox + a[0]
+				ops = append(ops, op{'L', []float64{x, y}})
+			}
+		case 'v':
+			if a, ok = args(1); ok {
+				y = oy + a[0]
+				ops = append(ops, op{'L', []float64{x, y}})
+			}
+		case 'c':
+			if a, ok = args(6); ok {
+				ops = append(ops, op{'C', []float64{ox + a[0], oy + a[1], ox + a[2], oy + a[3], ox + a[4], oy + a[5]}})
+				lastCtrlX, lastCtrlY = ox+a[2], oy+a[3]
+				x, y = ox+a[4], oy+a[5]
+			}
+		case 's':
+			if a, ok = args(4); ok {
+				x1, y1 := x, y
+				if lastCmd|0x20 == 'c' || lastCmd|0x20 == 's' {
+					x1, y1 = 2*x-lastCtrlX, 2*y-lastCtrlY
+				}
+				ops = append(ops, op{'C', []float64{x1, y1, ox + a[0], oy + a[1], ox + a[2], oy + a[3]}})
+				lastCtrlX, lastCtrlY = ox+a[0], oy+a[1]
+				x, y = ox+a[2], oy+a[3]
+			}
+		case 'q':
+			if a, ok = args(4); ok {
+				ops = append(ops, op{'Q', []float64{ox + a[0], oy + a[1], ox + a[2], oy + a[3]}})
+				lastCtrlX, lastCtrlY = ox+a[0], oy+a[1]
+				x, y = ox+a[2], oy+a[3]
+			}
+		case 't':
+			if a, ok = args(2); ok {
+				x1, y1 := x, y
+				if lastCmd|0x20 == 'q' || lastCmd|0x20 == 't' {
+					x1, y1 = 2*x-lastCtrlX, 2*y-lastCtrlY
+				}
+				ops = append(ops, op{'Q', []float64{x1, y1, ox + a[0], oy + a[1]}})
+				lastCtrlX, lastCtrlY = x1, y1
+				x, y = ox+a[0], oy+a[1]
+			}
+		case 'a':
+			var large, sweep bool
+			if a, ok = args(3); ok {
+				if large, ok = p.flag(); ok {
+					if sweep, ok = p.flag(); ok {
+						var end []float64
+						if end, ok = args(2); ok {
+							ex, ey := ox+end[0], oy+end[1]
+							ops = append(ops, arcOps(x, y, a[0], a[1], a[2], large, sweep, ex, ey)...)
+							x, y = ex, ey
+						}
+					}
+				}
+			}
+		case 'z':
+			ops = append(ops, op{cmd: 'Z'})
+			x, y = startX, startY
+			// close takes no numbers, so another command must follow
+			p.skipSeparators()
+			if p.i < len(p.s) {
+				c := p.s[p.i]
+				ok = (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+			}
+		default:
+			return nil, fmt.Errorf("unknown path command %c", cmd)
+		}
+		if !ok {
+			return nil, fmt.Errorf("bad path data near %q", p.s[p.i:])
+		}
+		lastCmd = cmd
+	}
+}
+
+// rectOps makes a rectangle, with rounded corners if rx or ry are given
+func rectOps(x, y, w, h, rx, ry float64) []op {
+	if w <= 0 || h <= 0 {
+		return nil
+	}
+	if rx == 0 {
+		rx = ry
+	}
+	if ry == 0 {
+		ry = rx
+	}
+	rx, ry = math.Min(rx, w/2), math.Min(ry, h/2)
+	if rx <= 0 {
+		return []op{{'M', []float64{x, y}}, {'L', []float64{x + w, y}}, {'L', []float64{x + w, y + h}}, {'L', []float64{x, y + h}}, {cmd: 'Z'}}
+	}
+	ops := []op{{'M', []float64{x + rx, y}}, {'L', []float64{x + w - rx, y}}}
+	ops = append(ops, arcOps(x+w-rx, y, rx, ry, 0, false, true, x+w, y+ry)...)
+	ops = append(ops, op{'L', []float64{x + w, y + h - ry}})
+	ops = append(ops, arcOps(x+w, y+h-ry, rx, ry, 0, false, true, x+w-rx, y+h)...)
+	ops = append(ops, op{'L', []float64{x + rx, y + h}})
+	ops = append(ops, arcOps(x+rx, y+h, rx, ry, 0, false, true, x, y+h-ry)...)
+	ops = append(ops, op{'L', []float64{x, y + ry}})
+	ops = append(ops, arcOps(x, y+ry, rx, ry, 0, false, true, x+rx, y)...)
+	return append(ops, op{cmd: 'Z'})
+}
+
+// ellipseOps makes an ellipse from two half arcs
+func ellipseOps(cx, cy, rx, ry float64) []op {
+	if rx <= 0 || ry <= 0 {
+		return nil
+	}
+	ops := []op{{'M', []float64{cx + rx, cy}}}
+	ops = append(ops, arcOps(cx+rx, cy, rx, ry, 0, false, true, cx-rx, cy)...)
+	ops = append(ops, arcOps(cx-rx, cy, rx, ry, 0, false, true, cx+rx, cy)...)
+	return append(ops, op{cmd: 'Z'})
+}
+
+// arcOps approximates an SVG elliptical arc with cubic curves,
+// following https://www.w3.org/TR/SVG/implnote.html#ArcImplementationNotes
+func arcOps(x1, y1, rx, ry, rotation float64, large, sweep bool, x2, y2 float64) []op {
+	if x1 == x2 && y1 == y2 {
+		return nil
+	}
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 {
+		return []op{{'L', []float64{x2, y2}}}
+	}
+	phi := rotation * math.Pi / 180
+	cos, sin := math.Cos(phi), math.Sin(phi)
+	dx, dy := (x1-x2)/2, (y1-y2)/2
+	x1p := cos*dx + sin*dy
+	y1p := -sin*dx + cos*dy
+	// scale up radii that are too small to reach
+	if l := x1p*x1p/(rx*rx) + y1p*y1p/(ry*ry); l > 1 {
+		rx, ry = rx*math.Sqrt(l), ry*math.Sqrt(l)
+	}
+	num := rx*rx*ry*ry - rx*rx*y1p*y1p - ry*ry*x1p*x1p
+	den := rx*rx*y1p*y1p + ry*ry*x1p*x1p
+	coef := math.Sqrt(math.Max(0, num/den))
+	if large == sweep {
+		coef = -coef
+	}
+	cxp, cyp := coef*rx*y1p/ry, -coef*ry*x1p/rx
+	cx := cos*cxp - sin*cyp + (x1+x2)/2
+	cy := sin*cxp + cos*cyp + (y1+y2)/2
+	angle := func(ux, uy, vx, vy float64) float64 {
+		return math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)
+	}
+	theta := angle(1, 0, (x1p-cxp)/rx, (y1p-cyp)/ry)
+	delta := angle((x1p-cxp)/rx, (y1p-cyp)/ry, (-x1p-cxp)/rx, (-y1p-cyp)/ry)
+	if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	} else if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	}
+	// at most a quarter turn per curve
+	n := int(math.Ceil(math.Abs(delta) / (math.Pi / 2)))
+	step := delta / float64(n)
+	k := 4.0 / 3.0 * math.Tan(step/4)
+	point := func(t float64) (float64, float64) {
+		x, y := rx*math.Cos(t), ry*math.Sin(t)
+		return cos*x - sin*y + cx, sin*x + cos*y + cy
+	}
+	deriv := func(t float64) (float64, float64) {
+		x, y := -rx*math.Sin(t), ry*math.Cos(t)
+		return cos*x - sin*y, sin*x + cos*y
+	}
+	var ops []op
+	for i := 0; i < n; i++ {
+		t1, t2 := theta+float64(i)*step, theta+float64(i+1)*step
+		px1, py1 := point(t1)
+		px2, py2 := point(t2)
+		dx1, dy1 := deriv(t1)
+		dx2, dy2 := deriv(t2)
+		ops = append(ops, op{'C', []float64{px1 + k*dx1, py1 + k*dy1, px2 - k*dx2, py2 - k*dy2, px2, py2}})
+	}
+	return ops
+}
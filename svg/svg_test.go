@@ -0,0 +1,62 @@
+package svg
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	doc, err := Parse(strings.NewReader(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 10 10">
+		<rect width="10" height="10" fill="#fff"/>
+		<g transform="translate(5,5)"><circle r="2" style="fill:red"/></g>
+		<path d="M0,10 l0-2 h2 v2 z" fill="blue"/>
+	</svg>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	img := doc.Render(100, 100)
+	for _, tc := range []struct {
+		x, y int
+		want color.RGBA
+	}{
+		{10, 10, color.RGBA{255, 255, 255, 255}},
+		{50, 50, color.RGBA{255, 0, 0, 255}},
+		{5, 95, color.RGBA{0, 0, 255, 255}},
+	} {
+		if got := color.RGBAModel.Convert(img.At(tc.x, tc.y)); got != tc.want {
+			t.Errorf("pixel at %d,%d is %v, want %v", tc.x, tc.y, got, tc.want)
+		}
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	ops, err := parsePath("M1 2L3-4.5e1zm1,1a1 1 0 1 0 2 0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ops[1].cmd != 'L' || ops[1].pts[1] != -45 {
+		t.Errorf("line is %v", ops[1])
+	}
+	if ops[3].cmd != 'M' || ops[3].pts[0] != 2 || ops[3].pts[1] != 3 {
+		t.Errorf("relative move after close is %v", ops[3])
+	}
+	last := ops[len(ops)-1]
+	if last.cmd != 'C' || last.pts[4] != 4 || last.pts[5] != 3 {
+		t.Errorf("arc ends at %v", last)
+	}
+	if _, err := parsePath("1 2"); err == nil {
+		t.Error("path data without a command should fail")
+	}
+	if _, err := parsePath("M0 0z1"); err == nil {
+		t.Error("numbers after a close should fail")
+	}
+}
+
+func TestWithOpacity(t *testing.T) {
+	c := withOpacity(color.NRGBA{R: 255, A: 128}, 0.5)
+	r, g, b, a := c.RGBA()
+	if g != 0 || b != 0 || a < 0x3f00 || a > 0x4100 || r != a {
+		t.Errorf("half opacity of translucent red is %x %x %x %x", r, g, b, a)
+	}
+}
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/fogleman/gg"
+	"github.com/hajimehoshi/ebiten/v2"
+	"oddstream.games/gosol/input"
+	"oddstream.games/gosol/schriftbank"
+	"oddstream.games/gosol/util"
+)
+
+// BookmarkEntry describes a bookmark, to be shown in the bookmarks drawer
+type BookmarkEntry struct {
+	ID        string // sent back in the change request when the bookmark is tapped or deleted
+	Name      string
+	Move      int
+	Current   bool // the bookmark is the current position, so tapping it renames it
+	Editing   bool // shown as a text input, for typing a new name
+	MaxLength int  // how long the new name can be, when Editing
+}
+
+// BookmarksDrawer lists the bookmarks
+type BookmarksDrawer struct {
+	DrawerBase
+}
+
+// NewBookmarksDrawer creates the BookmarksDrawer object; it starts life off screen to the left
+func NewBookmarksDrawer() *BookmarksDrawer {
+	d := &BookmarksDrawer{DrawerBase: DrawerBase{x: -300, y: 48, width: 300}} // height will be set when drawn
+	return d
+}
+
+// ShowBookmarksDrawer makes the bookmarks drawer visible
+func (u *UI) ShowBookmarksDrawer(entries []BookmarkEntry) {
+	con := u.VisibleDrawer()
+	if con != nil && con != u.bookmarksDrawer {
+		con.Hide()
+	}
+	d := u.bookmarksDrawer
+	d.widgets = d.widgets[:0]
+	d.widgets = append(d.widgets, NewNavItem(d, "bookmark_add", "Bookmark", "NewBookmark"))
+	var editing *TextInput
+	for _, e := range entries {
+		if e.Editing {
+			editing = NewTextInput(d, e.Name, "Bookmark name", "Rename bookmark", e.MaxLength)
+			d.widgets = append(d.widgets, editing)
+		} else {
+			d.widgets = append(d.widgets, NewBookmarkItem(d, e))
+		}
+	}
+	d.LayoutWidgets()
+	if con != d {
+		d.ResetScroll()
+		d.Show()
+	} else if d.aniState == aniLeft {
+		d.Show() // the drawer was closing, because a bookmark was tapped
+	}
+	if editing != nil {
+		editing.Focus()
+		editing.SelectAll()
+	}
+}
+
+// BookmarkItem is a widget that shows a bookmark; tapping it goes to the bookmark (or, if it is the current position,
+// renames it), tapping the cross deletes it
+type BookmarkItem struct {
+	WidgetBase
+	entry BookmarkEntry
+}
+
+func (w *BookmarkItem) createImg() *ebiten.Image {
+	dc := gg.NewContext(w.width, w.height)
+	if img, ok := IconMap["bookmark"]; ok && img != nil {
+		dc.DrawImage(img, 0, w.height/4)
+	}
+	dc.SetRGBA(1, 1, 1, 1)
+	// nota bene - text is drawn with y as a baseline
+	dc.SetFontFace(schriftbank.RobotoMedium24)
+	dc.DrawString(w.entry.Name, 48, float64(w.height)*0.8)
+	dc.SetFontFace(schriftbank.RobotoRegular14)
+	move := fmt.Sprintf("move %d", w.entry.Move)
+	if w.entry.Current {
+		move += ", here"
+	}
+	dc.DrawString(move, float64(util.Max(96, 48+textWidth(w.entry.Name)+12)), float64(w.height)*0.8)
+	img, ok := IconMap["close"]
+	if !ok || img == nil {
+		log.Fatal("close not in icon map")
+	}
+	dc.DrawImage(img, w.width-img.Bounds().Dx(), w.height/4)
+	return ebiten.NewImageFromImage(dc.Image())
+}
+
+// NewBookmarkItem creates a new BookmarkItem
+func NewBookmarkItem(parent Container, entry BookmarkEntry) *BookmarkItem {
+	width, _ := parent.Size()
+	w := &BookmarkItem{
+		// widget x, y will be set by LayoutWidgets
+		WidgetBase: WidgetBase{parent: parent, img: nil, width: width - 48, height: 48},
+		entry:      entry}
+	w.Activate()
+	return w
+}
+
+// Activate tells the input we need notifications
+func (w *BookmarkItem) Activate() {
+	w.disabled = false
+	w.img = w.createImg()
+}
+
+// Deactivate tells the input we no longer need notofications
+func (w *BookmarkItem) Deactivate() {
+	w.disabled = true
+	w.img = w.createImg()
+}
+
+// NotifyCallback is called by the Subject (Input/Stroke) when something interesting happens
+func (w *BookmarkItem) NotifyCallback(v input.StrokeEvent) {
+	if w.disabled {
+		return
+	}
+	switch v.Event {
+	case input.Tap:
+		if util.InRect(v.X, v.Y, w.OffsetRect) {
+			x0, _, x1, _ := w.OffsetRect()
+			if v.X > x1-(x1-x0)/5 {
+				cmdFn(ChangeRequest{ChangeRequested: "Delete bookmark", Data: w.entry.ID})
+			} else {
+				cmdFn(ChangeRequest{ChangeRequested: "Goto bookmark", Data: w.entry.ID})
+			}
+		}
+	}
+}
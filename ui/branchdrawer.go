@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"image"
+	"strconv"
+
+	"github.com/fogleman/gg"
+	"github.com/hajimehoshi/ebiten/v2"
+	"oddstream.games/gosol/input"
+	"oddstream.games/gosol/schriftbank"
+	"oddstream.games/gosol/util"
+)
+
+// BranchNode describes a position in the undo tree, to be shown in the branch drawer
+type BranchNode struct {
+	Index     int // identifies the position when it is tapped
+	Indent    int // how many branches away from the main line of play
+	Text      string
+	Thumbnail image.Image
+	Current   bool
+}
+
+// BranchDrawer shows the undo tree
+type BranchDrawer struct {
+	DrawerBase
+}
+
+// NewBranchDrawer creates the BranchDrawer object; it starts life off screen to the left
+func NewBranchDrawer() *BranchDrawer {
+	d := &BranchDrawer{DrawerBase: DrawerBase{x: -300, y: 48, width: 300}} // height will be set when drawn
+	return d
+}
+
+// ShowBranchDrawer makes the branch drawer visible
+func (u *UI) ShowBranchDrawer(nodes []BranchNode) {
+	con := u.VisibleDrawer()
+	if con == u.branchDrawer {
+		return
+	}
+	if con != nil {
+		con.Hide()
+	}
+	u.branchDrawer.widgets = u.branchDrawer.widgets[:0]
+	for _, n := range nodes {
+		u.branchDrawer.widgets = append(u.branchDrawer.widgets, NewBranchItem(u.branchDrawer, n))
+	}
+	u.branchDrawer.ResetScroll()
+	u.branchDrawer.LayoutWidgets()
+	u.branchDrawer.Show()
+}
+
+// BranchItem is a widget that shows a thumbnail of a position in the undo tree
+type BranchItem struct {
+	WidgetBase
+	node BranchNode
+}
+
+func (w *BranchItem) createImg() *ebiten.Image {
+	const indentWidth = 16
+	dc := gg.NewContext(w.width, w.height)
+	x := w.node.Indent * indentWidth
+	if x > w.width/2 {
+		x = w.width / 2
+	}
+	if w.node.Indent > 0 {
+		// a little branch line
+		dc.SetRGBA(1, 1, 1, 0.5)
+		dc.DrawLine(float64(x-indentWidth/2), 0, float64(x-indentWidth/2), float64(w.height/2))
+		dc.DrawLine(float64(x-indentWidth/2), float64(w.height/2), float64(x), float64(w.height/2))
+		dc.Stroke()
+	}
+	if w.node.Thumbnail != nil {
+		dc.DrawImage(w.node.Thumbnail, x, 0)
+		if w.node.Current {
+			dc.SetRGBA(1, 1, 1, 1)
+			dc.SetLineWidth(2)
+			b := w.node.Thumbnail.Bounds()
+			dc.DrawRectangle(float64(x), 0, float64(b.Dx()), float64(b.Dy()))
+			dc.Stroke()
+		}
+		x += w.node.Thumbnail.Bounds().Dx() + 8
+	}
+	dc.SetRGBA(1, 1, 1, 1)
+	dc.SetFontFace(schriftbank.RobotoRegular14)
+	dc.DrawStringWrapped(w.node.Text, float64(x), float64(w.height)/2, 0, 0.5, float64(w.width-x), 1.2, gg.AlignLeft)
+	return ebiten.NewImageFromImage(dc.Image())
+}
+
+// NewBranchItem creates a new BranchItem
+func NewBranchItem(parent Container, node BranchNode) *BranchItem {
+	width, _ := parent.Size()
+	height := 48
+	if node.Thumbnail != nil {
+		height = node.Thumbnail.Bounds().Dy()
+	}
+	w := &BranchItem{
+		// widget x, y will be set by LayoutWidgets
+		WidgetBase: WidgetBase{parent: parent, img: nil, width: width - 48, height: height},
+		node:       node}
+	w.Activate()
+	return w
+}
+
+// Activate tells the input we need notifications
+func (w *BranchItem) Activate() {
+	w.disabled = false
+	w.img = w.createImg()
+}
+
+// Deactivate tells the input we no longer need notofications
+func (w *BranchItem) Deactivate() {
+	w.disabled = true
+	w.img = w.createImg()
+}
+
+// NotifyCallback is called by the Subject (Input/Stroke) when something interesting happens
+func (w *BranchItem) NotifyCallback(v input.StrokeEvent) {
+	if w.disabled {
+		return
+	}
+	switch v.Event {
+	case input.Tap:
+		if util.InRect(v.X, v.Y, w.OffsetRect) {
+			cmdFn(ChangeRequest{ChangeRequested: "Goto position", Data: strconv.Itoa(w.node.Index)})
+		}
+	}
+}
@@ -13,7 +13,7 @@ import (
 type IconButton struct {
 	WidgetBase
 	iconName string
-	key      ebiten.Key
+	command  Command
 }
 
 func (b *IconButton) createImg() *ebiten.Image {
@@ -28,9 +28,9 @@ func (b *IconButton) createImg() *ebiten.Image {
 }
 
 // NewIconButton creates a new IconButton
-func NewIconButton(parent Container, x, y, width, height, align int, iconName string, key ebiten.Key) *IconButton {
+func NewIconButton(parent Container, x, y, width, height, align int, iconName string, command Command) *IconButton {
 	b := &IconButton{WidgetBase: WidgetBase{parent: parent, img: nil, x: x, y: y, width: width, height: height, align: align},
-		iconName: iconName, key: key}
+		iconName: iconName, command: command}
 	b.Activate()
 	return b
 }
@@ -57,8 +57,8 @@ func (b *IconButton) NotifyCallback(v input.StrokeEvent) {
 	switch v.Event {
 	case input.Tap:
 		if util.InRect(v.X, v.Y, b.OffsetRect) {
-			// println("IconButton sending command", b.key)
-			cmdFn(b.key)
+			// println("IconButton sending command", b.command)
+			cmdFn(b.command)
 		}
 	}
 }
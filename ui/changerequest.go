@@ -1,5 +1,8 @@
 package ui
 
+// Command is the name of something the player can ask for, sent by buttons and nav items (eg "Undo")
+type Command string
+
 type ChangeRequest struct {
 	ChangeRequested string
 	Data            string
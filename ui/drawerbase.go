@@ -21,9 +21,10 @@ type DrawerBase struct {
 	x, y             int
 	width, height    int
 	aniState         int
-	xOffset, yOffset int // used when dragging group of widgets
-	xOffsetBase      int // used when dragging group of widgets more than once
-	yOffsetBase      int // used when dragging group of widgets more than once
+	xOffset, yOffset int     // used when dragging group of widgets
+	xOffsetBase      int     // used when dragging group of widgets more than once
+	yOffsetBase      int     // used when dragging group of widgets more than once
+	grabbed          Dragger // the widget handling the current drag, if any
 }
 
 func (db *DrawerBase) createImg() *ebiten.Image {
@@ -118,23 +119,32 @@ func (db *DrawerBase) StartDrag(stroke *input.Stroke) bool {
 			stroke.Add(w)
 		}
 	}
+	x, y := stroke.Position()
+	if d, ok := db.FindWidgetAt(x, y).(Dragger); ok && !d.Disabled() && d.StartDrag(x, y) {
+		db.grabbed = d
+	}
 	return true
 }
 
 // DragBy this widget
 func (db *DrawerBase) DragBy(dx, dy int) {
+	if db.grabbed != nil {
+		return // the widget being dragged gets the moves itself
+	}
 	db.xOffset = db.xOffsetBase + dx
 	db.xOffset = util.ClampInt(db.xOffset, -db.width, 0)
 
-	numWidgets := len(db.widgets)
-	_, widgetHeight := db.widgets[0].Size()
-	widgetHeight += 24 // see Picker.LayoutWidgets, which uses (widget height) + 24 as vertical padding
+	// widgets may differ in height, so add them all up; see LayoutWidgets for the padding
+	contentHeight := 24
+	for _, w := range db.widgets {
+		_, widgetHeight := w.Size()
+		contentHeight += widgetHeight + 24
+	}
 	_, pickerHeight := db.Size()
-	// println("picker height", pickerHeight, "widget height", widgetHeight)
-	visibleWidgets := pickerHeight / widgetHeight
-	hiddenWidgets := numWidgets - visibleWidgets
-	// println("total", numWidgets, "visible", visibleWidgets, "hidden", hiddenWidgets)
-	heightOfHiddenWidgets := hiddenWidgets * widgetHeight
+	heightOfHiddenWidgets := contentHeight - pickerHeight
+	if heightOfHiddenWidgets < 0 {
+		heightOfHiddenWidgets = 0
+	}
 	db.yOffset = db.yOffsetBase + dy
 	db.yOffset = util.ClampInt(db.yOffset, -heightOfHiddenWidgets, 0)
 	db.LayoutWidgets()
@@ -150,6 +160,10 @@ func (db *DrawerBase) StopDrag() {
 		}
 	}
 	db.stroke = nil
+	if db.grabbed != nil {
+		db.grabbed.StopDrag()
+		db.grabbed = nil
+	}
 	// remember the amount of drag incase the widgets are dragged again
 	db.xOffsetBase = db.xOffset
 	db.yOffsetBase = db.yOffset
@@ -12,7 +12,7 @@ import (
 type FAB struct {
 	WidgetBase
 	iconName string
-	key      ebiten.Key
+	command  Command
 }
 
 func (f *FAB) createImg() *ebiten.Image {
@@ -27,8 +27,8 @@ func (f *FAB) createImg() *ebiten.Image {
 	return ebiten.NewImageFromImage(dc.Image())
 }
 
-func NewFAB(parent Container, iconName string, key ebiten.Key) *FAB {
-	f := &FAB{WidgetBase: WidgetBase{parent: parent, x: 0, y: 0, width: 72, height: 72}, iconName: iconName, key: key}
+func NewFAB(parent Container, iconName string, command Command) *FAB {
+	f := &FAB{WidgetBase: WidgetBase{parent: parent, x: 0, y: 0, width: 72, height: 72}, iconName: iconName, command: command}
 	f.Activate()
 	return f
 }
@@ -39,7 +39,7 @@ func (f *FAB) NotifyCallback(v input.StrokeEvent) {
 	switch v.Event {
 	case input.Tap:
 		if util.InRect(v.X, v.Y, f.OffsetRect) {
-			cmdFn(f.key)
+			cmdFn(f.command)
 		}
 	}
 }
@@ -89,9 +89,9 @@ func (fb *FABBar) Layout(outsideWidth, outsideHeight int) (int, int) {
 
 //
 
-func (u *UI) ShowFAB(iconName string, key ebiten.Key) {
+func (u *UI) ShowFAB(iconName string, command Command) {
 	u.fabbar.widgets = nil
-	u.fabbar.widgets = append(u.fabbar.widgets, NewFAB(u.fabbar, iconName, key))
+	u.fabbar.widgets = append(u.fabbar.widgets, NewFAB(u.fabbar, iconName, command))
 }
 
 func (u *UI) HideFAB() {
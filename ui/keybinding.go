@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"github.com/fogleman/gg"
+	"github.com/hajimehoshi/ebiten/v2"
+	"oddstream.games/gosol/input"
+	"oddstream.games/gosol/schriftbank"
+	"oddstream.games/gosol/util"
+)
+
+// KeyBinding is a widget that displays a command and the key bound to it; tapping it asks for a new key
+type KeyBinding struct {
+	WidgetBase
+	command string
+	binding string
+}
+
+func (w *KeyBinding) createImg() *ebiten.Image {
+	dc := gg.NewContext(w.width, w.height)
+	dc.SetRGBA(1, 1, 1, 1)
+	// nota bene - text is drawn with y as a baseline
+	dc.SetFontFace(schriftbank.RobotoMedium24)
+	dc.DrawString(w.command, 0, float64(w.height)*0.7)
+	dc.SetFontFace(schriftbank.RobotoRegular14)
+	dc.DrawStringAnchored(w.binding, float64(w.width), float64(w.height)*0.7, 1, 0)
+	return ebiten.NewImageFromImage(dc.Image())
+}
+
+// NewKeyBinding creates a new KeyBinding
+func NewKeyBinding(parent Container, command string, binding string) *KeyBinding {
+	width, _ := parent.Size()
+	w := &KeyBinding{
+		// widget x, y will be set by LayoutWidgets
+		WidgetBase: WidgetBase{parent: parent, img: nil, width: width - 48, height: 36},
+		command:    command, binding: binding}
+	w.Activate()
+	return w
+}
+
+// Activate tells the input we need notifications
+func (w *KeyBinding) Activate() {
+	w.disabled = false
+	w.img = w.createImg()
+}
+
+// Deactivate tells the input we no longer need notofications
+func (w *KeyBinding) Deactivate() {
+	w.disabled = true
+	w.img = w.createImg()
+}
+
+// NotifyCallback is called by the Subject (Input/Stroke) when something interesting happens
+func (w *KeyBinding) NotifyCallback(v input.StrokeEvent) {
+	if w.disabled {
+		return
+	}
+	switch v.Event {
+	case input.Tap:
+		if util.InRect(v.X, v.Y, w.OffsetRect) {
+			cmdFn(ChangeRequest{ChangeRequested: "Key binding", Data: w.command})
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package ui
+
+// KeyBindingsDrawer lists the commands and the keys bound to them
+type KeyBindingsDrawer struct {
+	DrawerBase
+}
+
+// NewKeyBindingsDrawer creates the KeyBindingsDrawer object; it starts life off screen to the left
+func NewKeyBindingsDrawer() *KeyBindingsDrawer {
+	d := &KeyBindingsDrawer{DrawerBase: DrawerBase{x: -300, y: 48, width: 300}} // height will be set when drawn
+	return d
+}
+
+// ShowKeyBindingsDrawer makes the key bindings drawer visible, listing commands in order with their bindings
+func (u *UI) ShowKeyBindingsDrawer(commands []string, bindings map[string]string) {
+	con := u.VisibleDrawer()
+	if con != nil && con != u.keyBindingsDrawer {
+		con.Hide()
+	}
+	u.keyBindingsDrawer.widgets = u.keyBindingsDrawer.widgets[:0]
+	for _, c := range commands {
+		u.keyBindingsDrawer.widgets = append(u.keyBindingsDrawer.widgets, NewKeyBinding(u.keyBindingsDrawer, c, bindings[c]))
+	}
+	u.keyBindingsDrawer.LayoutWidgets()
+	if con != u.keyBindingsDrawer {
+		u.keyBindingsDrawer.ResetScroll()
+		u.keyBindingsDrawer.Show()
+	}
+}
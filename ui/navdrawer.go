@@ -1,9 +1,5 @@
 package ui
 
-import (
-	"github.com/hajimehoshi/ebiten/v2"
-)
-
 // NavDrawer slide out modal menu
 type NavDrawer struct {
 	DrawerBase
@@ -15,18 +11,20 @@ func NewNavDrawer() *NavDrawer {
 	n := &NavDrawer{DrawerBase: DrawerBase{width: 256, height: 0, x: -256, y: 48}}
 	n.widgets = []Widget{
 		// widget x, y will be set by LayoutWidgets()
-		NewNavItem(n, "star", "New deal", ebiten.KeyN),
-		NewNavItem(n, "restore", "Restart deal", ebiten.KeyR),
-		NewNavItem(n, "search", "Find game...", ebiten.KeyF),
-		NewNavItem(n, "bookmark_add", "Bookmark", ebiten.KeyS),
-		NewNavItem(n, "bookmark", "Goto bookmark", ebiten.KeyL),
-		NewNavItem(n, "info", "Wikipedia...", ebiten.KeyF1),
-		NewNavItem(n, "list", "Statistics", ebiten.KeyF2),
-		NewNavItem(n, "settings", "Settings...", ebiten.KeyF3),
+		NewNavItem(n, "star", "New deal", "NewDeal"),
+		NewNavItem(n, "restore", "Restart deal", "RestartDeal"),
+		NewNavItem(n, "search", "Find game...", "FindGame"),
+		NewNavItem(n, "bookmark_add", "Bookmark", "Bookmark"),
+		NewNavItem(n, "bookmark", "Bookmarks...", "Bookmarks"),
+		NewNavItem(n, "undo", "Branches...", "Branches"),
+		NewNavItem(n, "info", "Wikipedia...", "Wikipedia"),
+		NewNavItem(n, "list", "Statistics", "Statistics"),
+		NewNavItem(n, "settings", "Settings...", "Settings"),
+		NewNavItem(n, "done", "Profiles...", "Profiles"),
 	}
 	// don't know how to ask a browser window to close
 	// if runtime.GOARCH != "wasm" {
-	// 	n.widgets = append(n.widgets, NewNavItem(n, "close", "Save and exit", ebiten.KeyX))
+	// 	n.widgets = append(n.widgets, NewNavItem(n, "close", "Save and exit", "Exit"))
 	// }
 	n.LayoutWidgets()
 	return n
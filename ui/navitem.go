@@ -15,7 +15,7 @@ type NavItem struct {
 	WidgetBase
 	iconName string
 	text     string
-	key      ebiten.Key
+	command  Command
 }
 
 func (n *NavItem) createImg() *ebiten.Image {
@@ -45,10 +45,10 @@ func (n *NavItem) createImg() *ebiten.Image {
 }
 
 // NewNavItem creates a new NavItem
-func NewNavItem(parent Container, iconName string, text string, key ebiten.Key) *NavItem {
+func NewNavItem(parent Container, iconName string, text string, command Command) *NavItem {
 	w, _ := parent.Size()
 	n := &NavItem{WidgetBase: WidgetBase{parent: parent, img: nil, x: -w, y: 0, width: w, height: 48, align: 0},
-		iconName: iconName, text: text, key: key}
+		iconName: iconName, text: text, command: command}
 	return n
 }
 
@@ -72,7 +72,7 @@ func (n *NavItem) NotifyCallback(v input.StrokeEvent) {
 	switch v.Event {
 	case input.Tap:
 		if util.InRect(v.X, v.Y, n.OffsetRect) {
-			cmdFn(n.key)
+			cmdFn(n.command)
 		}
 	}
 }
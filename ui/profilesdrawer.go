@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"log"
+
+	"github.com/fogleman/gg"
+	"github.com/hajimehoshi/ebiten/v2"
+	"oddstream.games/gosol/input"
+	"oddstream.games/gosol/schriftbank"
+	"oddstream.games/gosol/util"
+)
+
+// ProfileEntry describes a player profile, to be shown in the profiles drawer
+type ProfileEntry struct {
+	ID        string // sent back in the change request when the profile is tapped or deleted
+	Name      string
+	Current   bool
+	Editing   bool // shown as a text input, for typing a new name
+	MaxLength int  // how long the new name can be, when Editing
+}
+
+// ProfilesDrawer lists the player profiles
+type ProfilesDrawer struct {
+	DrawerBase
+}
+
+// NewProfilesDrawer creates the ProfilesDrawer object; it starts life off screen to the left
+func NewProfilesDrawer() *ProfilesDrawer {
+	d := &ProfilesDrawer{DrawerBase: DrawerBase{x: -300, y: 48, width: 300}} // height will be set when drawn
+	return d
+}
+
+// ShowProfilesDrawer makes the profiles drawer visible
+func (u *UI) ShowProfilesDrawer(entries []ProfileEntry) {
+	con := u.VisibleDrawer()
+	if con != nil && con != u.profilesDrawer {
+		con.Hide()
+	}
+	d := u.profilesDrawer
+	d.widgets = d.widgets[:0]
+	d.widgets = append(d.widgets, NewNavItem(d, "star", "New profile", "NewProfile"))
+	var editing *TextInput
+	for _, e := range entries {
+		if e.Editing {
+			editing = NewTextInput(d, e.Name, "Profile name", "Rename profile", e.MaxLength)
+			d.widgets = append(d.widgets, editing)
+		} else {
+			d.widgets = append(d.widgets, NewProfileItem(d, e))
+		}
+	}
+	d.LayoutWidgets()
+	if con != d {
+		d.ResetScroll()
+		d.Show()
+	} else if d.aniState == aniLeft {
+		d.Show() // the drawer was closing, because a profile was tapped
+	}
+	if editing != nil {
+		editing.Focus()
+		editing.SelectAll()
+	}
+}
+
+// ProfileItem is a widget that shows a profile; tapping it switches to the profile, tapping the cross
+// asks to delete it, and tapping again to confirm deletes it
+type ProfileItem struct {
+	WidgetBase
+	entry      ProfileEntry
+	confirming bool // the cross has been tapped, and has turned into Delete?
+}
+
+// deleteAreaWidth is how much of the right of a ProfileItem is taken by the cross
+const deleteAreaWidth = 48
+
+func (w *ProfileItem) createImg() *ebiten.Image {
+	dc := gg.NewContext(w.width, w.height)
+	iconName := "radio_button_unchecked"
+	if w.entry.Current {
+		iconName = "radio_button_checked"
+	}
+	if img, ok := IconMap[iconName]; ok && img != nil {
+		dc.DrawImage(img, 0, w.height/4)
+	}
+	dc.SetRGBA(1, 1, 1, 1)
+	// nota bene - text is drawn with y as a baseline
+	dc.SetFontFace(schriftbank.RobotoMedium24)
+	dc.DrawString(w.entry.Name, 48, float64(w.height)*0.8)
+	if w.confirming {
+		dc.SetRGBA(1, 0.3, 0.3, 1)
+		dc.DrawStringAnchored("Delete?", float64(w.width), float64(w.height)*0.8, 1, 0)
+	} else if !w.entry.Current {
+		img, ok := IconMap["close"]
+		if !ok || img == nil {
+			log.Fatal("close not in icon map")
+		}
+		dc.DrawImage(img, w.width-img.Bounds().Dx(), w.height/4)
+	}
+	return ebiten.NewImageFromImage(dc.Image())
+}
+
+// NewProfileItem creates a new ProfileItem
+func NewProfileItem(parent Container, entry ProfileEntry) *ProfileItem {
+	width, _ := parent.Size()
+	w := &ProfileItem{
+		// widget x, y will be set by LayoutWidgets
+		WidgetBase: WidgetBase{parent: parent, img: nil, width: width - 48, height: 48},
+		entry:      entry}
+	w.Activate()
+	return w
+}
+
+// Activate tells the input we need notifications
+func (w *ProfileItem) Activate() {
+	w.disabled = false
+	w.img = w.createImg()
+}
+
+// Deactivate tells the input we no longer need notofications
+func (w *ProfileItem) Deactivate() {
+	w.disabled = true
+	w.confirming = false
+	w.img = w.createImg()
+}
+
+// NotifyCallback is called by the Subject (Input/Stroke) when something interesting happens
+func (w *ProfileItem) NotifyCallback(v input.StrokeEvent) {
+	if w.disabled {
+		return
+	}
+	switch v.Event {
+	case input.Tap:
+		if !util.InRect(v.X, v.Y, w.OffsetRect) {
+			if w.confirming {
+				w.confirming = false // a tap anywhere else changes the player's mind
+				w.img = w.createImg()
+			}
+			return
+		}
+		_, _, x1, _ := w.OffsetRect()
+		switch {
+		case w.confirming && v.X > x1-textWidth("Delete?"):
+			cmdFn(ChangeRequest{ChangeRequested: "Delete profile", Data: w.entry.ID})
+		case w.confirming:
+			w.confirming = false
+			w.img = w.createImg()
+		case !w.entry.Current && v.X > x1-deleteAreaWidth:
+			w.confirming = true
+			w.img = w.createImg()
+		default:
+			cmdFn(ChangeRequest{ChangeRequested: "Switch profile", Data: w.entry.ID})
+		}
+	}
+}
@@ -16,6 +16,37 @@ type RadioButton struct {
 	WidgetBase
 	checked bool
 	text    string
+	group   *RadioGroup // nil for a button on its own
+}
+
+// RadioGroup ties RadioButtons together, so that checking one unchecks the others
+type RadioGroup struct {
+	text    string
+	buttons []*RadioButton
+}
+
+// NewRadioGroup makes a heading, and a RadioButton for each choice, for parent to show;
+// picking a choice sends a ChangeRequest for text with the choice as data
+func NewRadioGroup(parent Container, text string, choices []string, selected string) []Widget {
+	g := &RadioGroup{text: text}
+	widgets := []Widget{NewLabel(parent, -1, text, schriftbank.RobotoMedium24, "")}
+	for _, c := range choices {
+		b := NewRadioButton(parent, c, c == selected)
+		b.group = g
+		g.buttons = append(g.buttons, b)
+		widgets = append(widgets, b)
+	}
+	return widgets
+}
+
+func (g *RadioGroup) choose(chosen *RadioButton) {
+	for _, b := range g.buttons {
+		if checked := b == chosen; b.checked != checked {
+			b.checked = checked
+			b.img = b.createImg()
+		}
+	}
+	cmdFn(ChangeRequest{ChangeRequested: g.text, Data: chosen.text})
 }
 
 func (w *RadioButton) createImg() *ebiten.Image {
@@ -76,7 +107,14 @@ func (w *RadioButton) NotifyCallback(v input.StrokeEvent) {
 	}
 	switch v.Event {
 	case input.Tap:
-		if util.InRect(v.X, v.Y, w.OffsetRect) {
+		if !util.InRect(v.X, v.Y, w.OffsetRect) {
+			break
+		}
+		if w.group != nil {
+			if !w.checked {
+				w.group.choose(w)
+			}
+		} else {
 			w.checked = !w.checked
 			w.img = w.createImg()
 			cmdFn(ChangeRequest{ChangeRequested: w.text, Data: strconv.FormatBool(w.checked)})
@@ -0,0 +1,210 @@
+package ui
+
+import (
+	"runtime"
+	"strings"
+
+	"github.com/fogleman/gg"
+	"github.com/hajimehoshi/ebiten/v2"
+	"oddstream.games/gosol/input"
+	"oddstream.games/gosol/schriftbank"
+	"oddstream.games/gosol/util"
+)
+
+// names of the keys that don't type themselves
+const (
+	keyShift     = "Shift"
+	keyPaste     = "Paste"
+	keySpace     = "Space"
+	keyBackspace = "Back"
+	keyDone      = "Done"
+)
+
+const keyCapHeight = 48
+
+// screenKeyboard is the one on-screen keyboard, shared by all text inputs
+var screenKeyboard *ScreenKeyboard
+
+// the layout, in rows of keys; each letter key is 2 units wide, a row is 20 units
+var screenKeyboardRows = []string{"1234567890", "qwertyuiop", "asdfghjkl'", "zxcvbnm,.-"}
+
+// clipboardReadable is false where readClipboard can't work, as Android has none of the tools it runs
+var clipboardReadable = runtime.GOOS != "android"
+
+type keyLayout struct {
+	key   string
+	units int
+}
+
+// screenKeyboardBottomRow is Shift, Space and the keys that do things, without Paste if it can't work
+func screenKeyboardBottomRow() []keyLayout {
+	if !clipboardReadable {
+		return []keyLayout{{keyShift, 3}, {keySpace, 9}, {keyBackspace, 4}, {keyDone, 4}}
+	}
+	return []keyLayout{{keyShift, 3}, {keyPaste, 3}, {keySpace, 6}, {keyBackspace, 4}, {keyDone, 4}}
+}
+
+// ScreenKeyboard is typed on by tapping, for devices without a real keyboard.
+// It sits along the bottom of the screen while a TextInput has focus
+type ScreenKeyboard struct {
+	BarBase
+	visible      bool
+	shifted      bool // the next letter is upper case
+	screenHeight int
+}
+
+// keyCap is one key on the ScreenKeyboard
+type keyCap struct {
+	WidgetBase
+	key   string
+	units int
+}
+
+// NewScreenKeyboard creates the on-screen keyboard, hidden
+func NewScreenKeyboard() *ScreenKeyboard {
+	sk := &ScreenKeyboard{BarBase: BarBase{height: keyCapHeight * (len(screenKeyboardRows) + 1)}}
+	for _, row := range screenKeyboardRows {
+		for _, r := range row {
+			sk.widgets = append(sk.widgets, &keyCap{WidgetBase: WidgetBase{parent: sk, height: keyCapHeight}, key: string(r), units: 2})
+		}
+	}
+	for _, k := range screenKeyboardBottomRow() {
+		sk.widgets = append(sk.widgets, &keyCap{WidgetBase: WidgetBase{parent: sk, height: keyCapHeight}, key: k.key, units: k.units})
+	}
+	return sk
+}
+
+// label is what is shown on the key, which depends on the shift state
+func (k *keyCap) label() string {
+	if sk, ok := k.parent.(*ScreenKeyboard); ok && sk.shifted && len(k.key) == 1 {
+		return strings.ToUpper(k.key)
+	}
+	if k.key == keySpace {
+		return ""
+	}
+	return k.key
+}
+
+func (k *keyCap) createImg() *ebiten.Image {
+	if k.width == 0 || k.height == 0 {
+		return nil
+	}
+	dc := gg.NewContext(k.width, k.height)
+	dc.SetRGBA(1, 1, 1, 0.1)
+	dc.DrawRoundedRectangle(2, 2, float64(k.width-4), float64(k.height-4), 6)
+	dc.Fill()
+	if sk, ok := k.parent.(*ScreenKeyboard); ok && sk.shifted && k.key == keyShift {
+		dc.SetRGBA(100.0/255.0, 149.0/255.0, 237.0/255.0, 1) // CornflowerBlue
+	} else {
+		dc.SetRGBA(1, 1, 1, 1)
+	}
+	dc.SetFontFace(schriftbank.RobotoMedium24)
+	dc.DrawStringAnchored(k.label(), float64(k.width)/2, float64(k.height)/2, 0.5, 0.35)
+	return ebiten.NewImageFromImage(dc.Image())
+}
+
+// Activate this widget
+func (k *keyCap) Activate() {
+	k.disabled = false
+	k.img = k.createImg()
+}
+
+// Deactivate this widget
+func (k *keyCap) Deactivate() {
+	k.disabled = true
+	k.img = k.createImg()
+}
+
+// NotifyCallback is called by the Subject (Input/Stroke) when something interesting happens
+func (k *keyCap) NotifyCallback(v input.StrokeEvent) {
+	if k.disabled || focusedInput == nil {
+		return
+	}
+	switch v.Event {
+	case input.Tap:
+		if !util.InRect(v.X, v.Y, k.OffsetRect) {
+			return
+		}
+		sk := k.parent.(*ScreenKeyboard)
+		switch k.key {
+		case keyShift:
+			sk.setShifted(!sk.shifted)
+		case keySpace:
+			focusedInput.typeKey(" ")
+		default:
+			key := k.key
+			if len(key) == 1 && sk.shifted {
+				key = strings.ToUpper(key)
+				sk.setShifted(false) // shift only lasts for one letter
+			}
+			focusedInput.typeKey(key)
+		}
+	}
+}
+
+func (sk *ScreenKeyboard) setShifted(shifted bool) {
+	sk.shifted = shifted
+	for _, w := range sk.widgets {
+		w.Activate() // recreates the key image
+	}
+}
+
+// LayoutWidgets places the keys in rows, scaled to the width of the screen
+func (sk *ScreenKeyboard) LayoutWidgets() {
+	unit := float64(sk.width) / 20
+	x, y := 0, 0
+	for _, w := range sk.widgets {
+		k := w.(*keyCap)
+		if x >= 20 {
+			x = 0
+			y += keyCapHeight
+		}
+		k.SetPosition(int(float64(x)*unit), y)
+		k.width = int(float64(x+k.units)*unit) - int(float64(x)*unit)
+		k.img = k.createImg()
+		x += k.units
+	}
+}
+
+// Rect is empty while the keyboard is hidden, so it does not catch taps meant for what is underneath
+func (sk *ScreenKeyboard) Rect() (x0, y0, x1, y1 int) {
+	if !sk.visible {
+		return 0, 0, 0, 0
+	}
+	return sk.BarBase.Rect()
+}
+
+// Show the keyboard
+func (sk *ScreenKeyboard) Show() {
+	sk.visible = true
+	sk.setShifted(false)
+}
+
+// Hide the keyboard
+func (sk *ScreenKeyboard) Hide() {
+	sk.visible = false
+}
+
+// Visible is the keyboard
+func (sk *ScreenKeyboard) Visible() bool {
+	return sk.visible
+}
+
+// Layout implements Ebiten's Layout, putting the keyboard at the bottom of the screen
+func (sk *ScreenKeyboard) Layout(outsideWidth, outsideHeight int) (int, int) {
+	if sk.img == nil || outsideWidth != sk.width || outsideHeight != sk.screenHeight {
+		sk.width = outsideWidth
+		sk.screenHeight = outsideHeight
+		sk.y = outsideHeight - sk.height
+		sk.img = sk.createImg()
+		sk.LayoutWidgets()
+	}
+	return outsideWidth, outsideHeight
+}
+
+// Draw the keyboard, if it is showing
+func (sk *ScreenKeyboard) Draw(screen *ebiten.Image) {
+	if sk.visible {
+		sk.BarBase.Draw(screen)
+	}
+}
@@ -0,0 +1,46 @@
+package ui
+
+// SettingKind decides which widget shows a Setting
+type SettingKind int
+
+const (
+	BoolSetting    SettingKind = iota // a Checkbox
+	RangeSetting                      // a Slider
+	ColorSetting                      // a SwatchPicker
+	ChoiceSetting                     // a RadioGroup
+	CommandSetting                    // a NavItem
+)
+
+// Setting describes one entry in the settings drawer, with its current value.
+// Changing it sends a ChangeRequest with the Name, and the new value as Data
+type Setting struct {
+	Kind     SettingKind
+	Name     string
+	Bool     bool     // BoolSetting
+	Value    float64  // RangeSetting
+	Min, Max float64  //
+	Step     float64  //
+	Format   string   // RangeSetting, how the value is shown
+	Choice   string   // ChoiceSetting and ColorSetting, what is chosen now
+	Choices  []string // ChoiceSetting
+	Swatches []Swatch // ColorSetting
+	Icon     string   // CommandSetting
+	Command  Command  // CommandSetting
+}
+
+// widgets makes the widget(s) that show the setting
+func (s Setting) widgets(parent Container) []Widget {
+	switch s.Kind {
+	case BoolSetting:
+		return []Widget{NewCheckbox(parent, s.Name, s.Bool)}
+	case RangeSetting:
+		return []Widget{NewSlider(parent, s.Name, s.Value, s.Min, s.Max, s.Step, s.Format)}
+	case ColorSetting:
+		return []Widget{NewSwatchPicker(parent, s.Name, s.Swatches, s.Choice)}
+	case ChoiceSetting:
+		return NewRadioGroup(parent, s.Name, s.Choices, s.Choice)
+	case CommandSetting:
+		return []Widget{NewNavItem(parent, s.Icon, s.Name, s.Command)}
+	}
+	return nil
+}
@@ -12,8 +12,8 @@ func NewSettingsDrawer() *SettingsDrawer {
 	return d
 }
 
-// ShowSettingsDrawer makes the card back picker visible
-func (u *UI) ShowSettingsDrawer(booleanSettings map[string]bool) {
+// ShowSettingsDrawer makes the settings drawer visible, with a widget (or a few) for each setting
+func (u *UI) ShowSettingsDrawer(settings []Setting) {
 	con := u.VisibleDrawer()
 	if con == u.settingsDrawer {
 		return
@@ -21,15 +21,28 @@ func (u *UI) ShowSettingsDrawer(booleanSettings map[string]bool) {
 	if con != nil {
 		con.Hide()
 	}
-	u.settingsDrawer.widgets = u.settingsDrawer.widgets[:0]
-	u.settingsDrawer.widgets = []Widget{
-		// widget x, y will be set by LayoutWidgets()
-		NewCheckbox(u.settingsDrawer, "Fixed cards", booleanSettings["FixedCards"]),
-		NewCheckbox(u.settingsDrawer, "Power moves", booleanSettings["PowerMoves"]),
-		NewCheckbox(u.settingsDrawer, "Four colors", booleanSettings["FourColors"]),
-		NewCheckbox(u.settingsDrawer, "Mirror baize", booleanSettings["MirrorBaize"]),
-		NewCheckbox(u.settingsDrawer, "Mute sounds", booleanSettings["Mute"]),
-	}
+	u.settingsDrawer.setWidgets(settings)
+	u.settingsDrawer.ResetScroll()
 	u.settingsDrawer.LayoutWidgets()
 	u.settingsDrawer.Show()
 }
+
+// UpdateSettingsDrawer shows changed settings, if the settings drawer is open, keeping its scroll position
+func (u *UI) UpdateSettingsDrawer(settings []Setting) {
+	if !u.settingsDrawer.Visible() {
+		return
+	}
+	u.settingsDrawer.setWidgets(settings)
+	u.settingsDrawer.LayoutWidgets()
+}
+
+func (d *SettingsDrawer) setWidgets(settings []Setting) {
+	d.widgets = d.widgets[:0]
+	for _, s := range settings {
+		// widget x, y will be set by LayoutWidgets()
+		d.widgets = append(d.widgets, s.widgets(d)...)
+	}
+	for _, w := range d.widgets {
+		w.Activate()
+	}
+}
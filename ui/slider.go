@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/fogleman/gg"
+	"github.com/hajimehoshi/ebiten/v2"
+	"oddstream.games/gosol/input"
+	"oddstream.games/gosol/schriftbank"
+	"oddstream.games/gosol/util"
+)
+
+const sliderKnobRadius = 8
+
+// Slider lets the player pick a number from a range, by tapping or dragging along a track
+type Slider struct {
+	WidgetBase
+	text                  string
+	value, min, max, step float64
+	format                string // how the value is shown, eg "%.2f"
+	dragging              bool
+}
+
+func (w *Slider) createImg() *ebiten.Image {
+	dc := gg.NewContext(w.width, w.height)
+
+	dc.SetRGBA(1, 1, 1, 1)
+	dc.SetFontFace(schriftbank.RobotoMedium24)
+	dc.DrawString(w.text, 0, 30)
+	dc.DrawStringAnchored(fmt.Sprintf(w.format, w.value), float64(w.width), 30, 1, 0)
+
+	x0, x1, y := float64(sliderKnobRadius), float64(w.width-sliderKnobRadius), float64(w.height-sliderKnobRadius-4)
+	x := x0
+	if w.max > w.min {
+		x += (x1 - x0) * (w.value - w.min) / (w.max - w.min)
+	}
+	dc.SetLineWidth(4)
+	dc.SetRGBA(1, 1, 1, 0.25)
+	dc.DrawLine(x, y, x1, y)
+	dc.Stroke()
+	dc.SetRGBA(1, 1, 1, 1)
+	dc.DrawLine(x0, y, x, y)
+	dc.Stroke()
+	dc.DrawCircle(x, y, sliderKnobRadius)
+	dc.Fill()
+
+	return ebiten.NewImageFromImage(dc.Image())
+}
+
+// NewSlider creates a new Slider; value is rounded to the nearest step
+func NewSlider(parent Container, text string, value, min, max, step float64, format string) *Slider {
+	width, _ := parent.Size()
+	w := &Slider{
+		WidgetBase: WidgetBase{parent: parent, img: nil, x: 0, y: 0, width: width - 48, height: 72},
+		text:       text, min: min, max: max, step: step, format: format}
+	w.value = w.round(value)
+	w.Activate()
+	return w
+}
+
+// round clamps v to the range, and snaps it to a step
+func (w *Slider) round(v float64) float64 {
+	if w.step > 0 {
+		v = w.min + math.Round((v-w.min)/w.step)*w.step
+	}
+	return math.Max(w.min, math.Min(w.max, v))
+}
+
+// valueAt gives the value under screen x
+func (w *Slider) valueAt(x int) float64 {
+	x0, _, x1, _ := w.OffsetRect()
+	track := float64(x1 - x0 - 2*sliderKnobRadius)
+	if track <= 0 {
+		return w.value
+	}
+	return w.round(w.min + (w.max-w.min)*float64(x-x0-sliderKnobRadius)/track)
+}
+
+func (w *Slider) setValue(v float64) {
+	if v != w.value {
+		w.value = v
+		w.img = w.createImg()
+	}
+}
+
+func (w *Slider) send() {
+	cmdFn(ChangeRequest{ChangeRequested: w.text, Data: strconv.FormatFloat(w.value, 'f', -1, 64)})
+}
+
+// Activate tells the input we need notifications
+func (w *Slider) Activate() {
+	w.disabled = false
+	w.img = w.createImg()
+}
+
+// Deactivate tells the input we no longer need notofications
+func (w *Slider) Deactivate() {
+	w.disabled = true
+	w.dragging = false
+	w.img = w.createImg()
+}
+
+// StartDrag implements Dragger; a drag that starts on the slider moves the knob rather than scrolling the drawer
+func (w *Slider) StartDrag(x, y int) bool {
+	if w.disabled || !util.InRect(x, y, w.OffsetRect) {
+		return false
+	}
+	w.dragging = true
+	return true
+}
+
+// StopDrag implements Dragger, sending the value the knob was dragged to
+func (w *Slider) StopDrag() {
+	if w.dragging {
+		w.dragging = false
+		w.send()
+	}
+}
+
+// NotifyCallback is called by the Subject (Input/Stroke) when something interesting happens
+func (w *Slider) NotifyCallback(v input.StrokeEvent) {
+	if w.disabled {
+		return
+	}
+	switch v.Event {
+	case input.Tap:
+		if util.InRect(v.X, v.Y, w.OffsetRect) {
+			w.dragging = false // a tap is not a drag, so StopDrag has nothing to send
+			w.setValue(w.valueAt(v.X))
+			w.send()
+		}
+	case input.Move:
+		if w.dragging {
+			w.setValue(w.valueAt(v.X))
+		}
+	}
+}
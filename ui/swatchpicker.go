@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"image/color"
+
+	"github.com/fogleman/gg"
+	"github.com/hajimehoshi/ebiten/v2"
+	"oddstream.games/gosol/input"
+	"oddstream.games/gosol/schriftbank"
+	"oddstream.games/gosol/util"
+)
+
+const (
+	swatchSize    = 24
+	swatchGap     = 8
+	swatchHeading = 40 // height of the text above the swatches
+)
+
+// Swatch is a named color offered by a SwatchPicker
+type Swatch struct {
+	Name  string
+	Color color.Color
+}
+
+// SwatchPicker shows a heading above rows of colored squares; tapping a square picks that color
+type SwatchPicker struct {
+	WidgetBase
+	text     string
+	swatches []Swatch
+	selected string // name of the picked swatch
+}
+
+func (w *SwatchPicker) perRow() int {
+	if n := (w.width + swatchGap) / (swatchSize + swatchGap); n > 0 {
+		return n
+	}
+	return 1
+}
+
+func (w *SwatchPicker) createImg() *ebiten.Image {
+	dc := gg.NewContext(w.width, w.height)
+
+	dc.SetRGBA(1, 1, 1, 1)
+	dc.SetFontFace(schriftbank.RobotoMedium24)
+	dc.DrawString(w.text, 0, 30)
+
+	for i, s := range w.swatches {
+		x := float64((i%w.perRow())*(swatchSize+swatchGap) + swatchGap/2)
+		y := float64(swatchHeading + (i/w.perRow())*(swatchSize+swatchGap) + swatchGap/2)
+		dc.SetColor(s.Color)
+		dc.DrawRectangle(x, y, swatchSize, swatchSize)
+		dc.Fill()
+		if s.Name == w.selected {
+			dc.SetRGBA(1, 1, 1, 1)
+			dc.SetLineWidth(3)
+			dc.DrawRectangle(x-2, y-2, swatchSize+4, swatchSize+4)
+			dc.Stroke()
+		} else {
+			// so dark swatches show up against the drawer
+			dc.SetRGBA(1, 1, 1, 0.25)
+			dc.SetLineWidth(1)
+			dc.DrawRectangle(x, y, swatchSize, swatchSize)
+			dc.Stroke()
+		}
+	}
+
+	return ebiten.NewImageFromImage(dc.Image())
+}
+
+// NewSwatchPicker creates a new SwatchPicker
+func NewSwatchPicker(parent Container, text string, swatches []Swatch, selected string) *SwatchPicker {
+	width, _ := parent.Size()
+	w := &SwatchPicker{
+		WidgetBase: WidgetBase{parent: parent, img: nil, x: 0, y: 0, width: width - 48},
+		text:       text, swatches: swatches, selected: selected}
+	rows := (len(swatches) + w.perRow() - 1) / w.perRow()
+	w.height = swatchHeading + rows*(swatchSize+swatchGap)
+	w.Activate()
+	return w
+}
+
+// swatchAt returns the index of the swatch at screen x, y, or -1
+func (w *SwatchPicker) swatchAt(x, y int) int {
+	x0, y0, _, _ := w.OffsetRect()
+	x, y = x-x0, y-y0-swatchHeading
+	if x < 0 || y < 0 {
+		return -1
+	}
+	col, row := x/(swatchSize+swatchGap), y/(swatchSize+swatchGap)
+	if col >= w.perRow() {
+		return -1
+	}
+	if i := row*w.perRow() + col; i < len(w.swatches) {
+		return i
+	}
+	return -1
+}
+
+// Activate tells the input we need notifications
+func (w *SwatchPicker) Activate() {
+	w.disabled = false
+	w.img = w.createImg()
+}
+
+// Deactivate tells the input we no longer need notofications
+func (w *SwatchPicker) Deactivate() {
+	w.disabled = true
+	w.img = w.createImg()
+}
+
+// NotifyCallback is called by the Subject (Input/Stroke) when something interesting happens
+func (w *SwatchPicker) NotifyCallback(v input.StrokeEvent) {
+	if w.disabled {
+		return
+	}
+	switch v.Event {
+	case input.Tap:
+		if !util.InRect(v.X, v.Y, w.OffsetRect) {
+			break
+		}
+		if i := w.swatchAt(v.X, v.Y); i >= 0 && w.swatches[i].Name != w.selected {
+			w.selected = w.swatches[i].Name
+			w.img = w.createImg()
+			cmdFn(ChangeRequest{ChangeRequested: w.text, Data: w.selected})
+		}
+	}
+}
@@ -0,0 +1,404 @@
+package ui
+
+import (
+	"runtime"
+	"unicode"
+
+	"github.com/fogleman/gg"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"golang.org/x/image/font"
+	"oddstream.games/gosol/input"
+	"oddstream.games/gosol/schriftbank"
+	"oddstream.games/gosol/util"
+)
+
+const textInputPadding = 8
+
+// focusedInput is the TextInput that typing goes to, if any
+var focusedInput *TextInput
+
+// TextInput is a one line box that can be typed into. Pressing Enter (or Done on the on-screen keyboard)
+// sends a ChangeRequest with the text as data; Escape gives up typing
+type TextInput struct {
+	WidgetBase
+	text        []rune
+	hint        string // shown, faintly, while there is no text
+	requestType string
+	maxLength   int
+	caret       int // typing goes in before text[caret]
+	anchor      int // the other end of the selection; the same as caret when nothing is selected
+	scroll      int // pixels of text scrolled off the left, to keep the caret in view
+	selecting   bool
+	blink       int // ticks since the caret last moved, for blinking it
+	pasted      chan string
+}
+
+// NewTextInput creates a new TextInput, holding text, which can be up to maxLength letters long
+func NewTextInput(parent Container, text, hint, requestType string, maxLength int) *TextInput {
+	width, _ := parent.Size()
+	w := &TextInput{
+		WidgetBase: WidgetBase{parent: parent, img: nil, x: 0, y: 0, width: width - 48, height: 48},
+		hint:       hint, requestType: requestType, maxLength: maxLength,
+		pasted: make(chan string, 1)}
+	w.text = w.clean([]rune(text))
+	w.caret, w.anchor = len(w.text), len(w.text)
+	w.Activate()
+	return w
+}
+
+func textWidth(s string) int {
+	return font.MeasureString(schriftbank.RobotoMedium24, s).Ceil()
+}
+
+// caretX is the x position of index i in the text, ignoring scroll
+func (w *TextInput) caretX(i int) int {
+	return textWidth(string(w.text[:i]))
+}
+
+// indexAt gives the text index nearest to screen x
+func (w *TextInput) indexAt(x int) int {
+	x0, _, _, _ := w.OffsetRect()
+	x = x - x0 - textInputPadding + w.scroll
+	best, bestDist := 0, -1
+	for i := 0; i <= len(w.text); i++ {
+		d := w.caretX(i) - x
+		if d < 0 {
+			d = -d
+		}
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
+
+func (w *TextInput) focused() bool {
+	return focusedInput == w
+}
+
+func (w *TextInput) createImg() *ebiten.Image {
+	dc := gg.NewContext(w.width, w.height)
+
+	dc.SetRGBA(1, 1, 1, 0.05)
+	dc.DrawRectangle(0, 0, float64(w.width), float64(w.height))
+	dc.Fill()
+
+	// keep the caret in view
+	visible := w.width - textInputPadding*2
+	if cx := w.caretX(w.caret); cx-w.scroll > visible {
+		w.scroll = cx - visible
+	} else if cx < w.scroll {
+		w.scroll = cx
+	}
+
+	dc.DrawRectangle(textInputPadding, 0, float64(visible), float64(w.height))
+	dc.Clip()
+	x := float64(textInputPadding - w.scroll)
+	baseline := float64(w.height) * 0.7
+	if w.anchor != w.caret {
+		from, to := w.selection()
+		dc.SetRGBA(100.0/255.0, 149.0/255.0, 237.0/255.0, 0.5) // CornflowerBlue
+		dc.DrawRectangle(x+float64(w.caretX(from)), 8, float64(w.caretX(to)-w.caretX(from)), float64(w.height-16))
+		dc.Fill()
+	}
+	dc.SetFontFace(schriftbank.RobotoMedium24)
+	if len(w.text) == 0 && !w.focused() {
+		dc.SetRGBA(1, 1, 1, 0.5)
+		dc.DrawString(w.hint, x, baseline)
+	} else {
+		dc.SetRGBA(1, 1, 1, 1)
+		dc.DrawString(string(w.text), x, baseline)
+	}
+	if w.focused() && (w.blink/30)%2 == 0 {
+		cx := x + float64(w.caretX(w.caret))
+		dc.SetLineWidth(2)
+		dc.DrawLine(cx, 8, cx, float64(w.height-8))
+		dc.Stroke()
+	}
+	dc.ResetClip()
+
+	// Material-ish underline, bolder while typing
+	if w.focused() {
+		dc.SetRGBA(100.0/255.0, 149.0/255.0, 237.0/255.0, 1)
+		dc.DrawRectangle(0, float64(w.height-3), float64(w.width), 3)
+	} else {
+		dc.SetRGBA(1, 1, 1, 0.5)
+		dc.DrawRectangle(0, float64(w.height-1), float64(w.width), 1)
+	}
+	dc.Fill()
+
+	return ebiten.NewImageFromImage(dc.Image())
+}
+
+// Text returns what has been typed
+func (w *TextInput) Text() string {
+	return string(w.text)
+}
+
+// SelectAll selects all the text, so typing replaces it
+func (w *TextInput) SelectAll() {
+	w.anchor, w.caret = 0, len(w.text)
+	w.changed()
+}
+
+// Focus makes typing go to this input, bringing up the on-screen keyboard if there is probably no real one
+func (w *TextInput) Focus() {
+	if focusedInput != nil && focusedInput != w {
+		focusedInput.Unfocus()
+	}
+	focusedInput = w
+	if wantScreenKeyboard() && screenKeyboard != nil {
+		screenKeyboard.Show()
+	}
+	w.changed()
+}
+
+// Unfocus stops typing going to this input
+func (w *TextInput) Unfocus() {
+	if focusedInput != w {
+		return
+	}
+	focusedInput = nil
+	w.selecting = false
+	if screenKeyboard != nil {
+		screenKeyboard.Hide()
+	}
+	w.changed()
+}
+
+// wantScreenKeyboard is true when the player is touching the screen, so probably has no keyboard
+func wantScreenKeyboard() bool {
+	return runtime.GOOS == "android" ||
+		len(ebiten.AppendTouchIDs(nil)) > 0 ||
+		len(inpututil.AppendJustReleasedTouchIDs(nil)) > 0
+}
+
+func (w *TextInput) changed() {
+	w.blink = 0
+	w.img = w.createImg()
+}
+
+func (w *TextInput) selection() (int, int) {
+	if w.anchor < w.caret {
+		return w.anchor, w.caret
+	}
+	return w.caret, w.anchor
+}
+
+// clean removes line breaks and other control characters, and anything past the maximum length
+func (w *TextInput) clean(rs []rune) []rune {
+	var out []rune
+	for _, r := range rs {
+		if unicode.IsPrint(r) {
+			out = append(out, r)
+		}
+	}
+	if w.maxLength > 0 && len(out) > w.maxLength {
+		out = out[:w.maxLength]
+	}
+	return out
+}
+
+// deleteSelection removes the selected text, returning false if nothing was selected
+func (w *TextInput) deleteSelection() bool {
+	if w.anchor == w.caret {
+		return false
+	}
+	from, to := w.selection()
+	w.text = append(w.text[:from], w.text[to:]...)
+	w.caret, w.anchor = from, from
+	return true
+}
+
+// insert puts rs in place of the selection
+func (w *TextInput) insert(rs []rune) {
+	w.deleteSelection()
+	rs = w.clean(rs)
+	if w.maxLength > 0 && len(w.text)+len(rs) > w.maxLength {
+		rs = rs[:w.maxLength-len(w.text)]
+	}
+	text := make([]rune, 0, len(w.text)+len(rs))
+	text = append(text, w.text[:w.caret]...)
+	text = append(text, rs...)
+	w.text = append(text, w.text[w.caret:]...)
+	w.caret += len(rs)
+	w.anchor = w.caret
+	w.changed()
+}
+
+// backspace deletes the selection, or the letter before the caret
+func (w *TextInput) backspace() {
+	if !w.deleteSelection() && w.caret > 0 {
+		w.text = append(w.text[:w.caret-1], w.text[w.caret:]...)
+		w.caret--
+		w.anchor = w.caret
+	}
+	w.changed()
+}
+
+// deleteForward deletes the selection, or the letter after the caret
+func (w *TextInput) deleteForward() {
+	if !w.deleteSelection() && w.caret < len(w.text) {
+		w.text = append(w.text[:w.caret], w.text[w.caret+1:]...)
+	}
+	w.changed()
+}
+
+// moveCaret moves the caret to i, extending the selection if extend
+func (w *TextInput) moveCaret(i int, extend bool) {
+	w.caret = util.ClampInt(i, 0, len(w.text))
+	if !extend {
+		w.anchor = w.caret
+	}
+	w.changed()
+}
+
+// submit sends the text, and stops typing
+func (w *TextInput) submit() {
+	w.Unfocus()
+	cmdFn(ChangeRequest{ChangeRequested: w.requestType, Data: string(w.text)})
+}
+
+// keyRepeated is true when a key has just been pressed, or has been held down long enough to repeat
+func keyRepeated(k ebiten.Key) bool {
+	d := inpututil.KeyPressDuration(k)
+	return d == 1 || (d >= 30 && (d-30)%3 == 0)
+}
+
+// Update handles typing while the input has focus
+func (w *TextInput) Update() {
+	if !w.focused() {
+		return
+	}
+	select {
+	case s := <-w.pasted:
+		w.insert([]rune(s))
+	default:
+	}
+
+	// includes characters composed with an IME
+	if chars := ebiten.AppendInputChars(nil); len(chars) > 0 {
+		w.insert(chars)
+	}
+
+	ctrl := ebiten.IsKeyPressed(ebiten.KeyControl) || ebiten.IsKeyPressed(ebiten.KeyMeta)
+	shift := ebiten.IsKeyPressed(ebiten.KeyShift)
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter), inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter):
+		w.submit()
+		return
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		w.Unfocus()
+		return
+	case keyRepeated(ebiten.KeyBackspace):
+		w.backspace()
+	case keyRepeated(ebiten.KeyDelete):
+		w.deleteForward()
+	case keyRepeated(ebiten.KeyArrowLeft):
+		if from, _ := w.selection(); w.anchor != w.caret && !shift {
+			w.moveCaret(from, false)
+		} else {
+			w.moveCaret(w.caret-1, shift)
+		}
+	case keyRepeated(ebiten.KeyArrowRight):
+		if _, to := w.selection(); w.anchor != w.caret && !shift {
+			w.moveCaret(to, false)
+		} else {
+			w.moveCaret(w.caret+1, shift)
+		}
+	case inpututil.IsKeyJustPressed(ebiten.KeyHome):
+		w.moveCaret(0, shift)
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnd):
+		w.moveCaret(len(w.text), shift)
+	case ctrl && inpututil.IsKeyJustPressed(ebiten.KeyA):
+		w.SelectAll()
+	case clipboardReadable && ((ctrl && inpututil.IsKeyJustPressed(ebiten.KeyV)) || (shift && inpututil.IsKeyJustPressed(ebiten.KeyInsert))):
+		go readClipboard(w.pasted)
+	}
+
+	w.blink++
+	if w.blink%30 == 0 {
+		w.img = w.createImg()
+	}
+}
+
+// Activate tells the input we need notifications
+func (w *TextInput) Activate() {
+	w.disabled = false
+	w.img = w.createImg()
+}
+
+// Deactivate tells the input we no longer need notofications
+func (w *TextInput) Deactivate() {
+	w.disabled = true
+	w.Unfocus()
+	w.img = w.createImg()
+}
+
+// StartDrag implements Dragger; dragging across the text selects it
+func (w *TextInput) StartDrag(x, y int) bool {
+	if w.disabled || !util.InRect(x, y, w.OffsetRect) {
+		return false
+	}
+	w.Focus()
+	w.selecting = true
+	w.moveCaret(w.indexAt(x), false)
+	return true
+}
+
+// StopDrag implements Dragger
+func (w *TextInput) StopDrag() {
+	w.selecting = false
+}
+
+// NotifyCallback is called by the Subject (Input/Stroke) when something interesting happens
+func (w *TextInput) NotifyCallback(v input.StrokeEvent) {
+	if w.disabled {
+		return
+	}
+	switch v.Event {
+	case input.Tap:
+		if util.InRect(v.X, v.Y, w.OffsetRect) {
+			w.Focus()
+			w.moveCaret(w.indexAt(v.X), false)
+		}
+	case input.Move:
+		if w.selecting {
+			w.moveCaret(w.indexAt(v.X), true)
+		}
+	case input.LongPress:
+		if util.InRect(v.X, v.Y, w.OffsetRect) {
+			// select the word under the finger
+			i := w.indexAt(v.X)
+			from, to := i, i
+			for from > 0 && !unicode.IsSpace(w.text[from-1]) {
+				from--
+			}
+			for to < len(w.text) && !unicode.IsSpace(w.text[to]) {
+				to++
+			}
+			w.anchor, w.caret = from, to
+			w.changed()
+		}
+	}
+}
+
+// typeKey is called by the on-screen keyboard
+func (w *TextInput) typeKey(key string) {
+	switch key {
+	case keyBackspace:
+		w.backspace()
+	case keyDone:
+		w.submit()
+	case keyPaste:
+		go readClipboard(w.pasted)
+	default:
+		w.insert([]rune(key))
+	}
+}
+
+// Typing is true while a TextInput has focus, when key presses should not be taken as commands
+func (u *UI) Typing() bool {
+	return focusedInput != nil
+}
@@ -1,7 +1,6 @@
 package ui
 
 import (
-	"github.com/hajimehoshi/ebiten/v2"
 	"oddstream.games/gosol/schriftbank"
 )
 
@@ -17,11 +16,11 @@ func NewToolbar() *Toolbar {
 
 	tb.widgets = []Widget{
 		// button's x will be set by LayoutWidgets() (y will always be 0 in a toolbar)
-		NewIconButton(tb, 0, 0, 48, 48, -1, "menu", ebiten.KeyMenu),
+		NewIconButton(tb, 0, 0, 48, 48, -1, "menu", "Menu"),
 		NewLabel(tb, 0, "title", schriftbank.RobotoMedium24, ""),
-		NewIconButton(tb, 0, 0, 48, 48, 1, "undo", ebiten.KeyU),      // U for Undo
-		NewIconButton(tb, 0, 0, 48, 48, 1, "done", ebiten.KeyC),      // C for Collect
-		NewIconButton(tb, 0, 0, 48, 48, 1, "lightbulb", ebiten.KeyH), // H for Hint
+		NewIconButton(tb, 0, 0, 48, 48, 1, "undo", "Undo"),
+		NewIconButton(tb, 0, 0, 48, 48, 1, "done", "Collect"),
+		NewIconButton(tb, 0, 0, 48, 48, 1, "lightbulb", "Hint"),
 	}
 	return tb
 }
@@ -16,17 +16,22 @@ var (
 
 // UI encapsulates a complete user interface that can be rendered onto the screen.
 type UI struct {
-	toolbar        *Toolbar
-	statusbar      *Statusbar
-	fabbar         *FABBar
-	navDrawer      *NavDrawer
-	settingsDrawer *SettingsDrawer
-	variantPicker  *Picker
-	textDrawer     *TextDrawer
-	containers     []Container
-	bars           []Container
-	drawers        []Container
-	toastManager   *ToastManager
+	toolbar           *Toolbar
+	statusbar         *Statusbar
+	fabbar            *FABBar
+	navDrawer         *NavDrawer
+	settingsDrawer    *SettingsDrawer
+	variantPicker     *Picker
+	textDrawer        *TextDrawer
+	keyBindingsDrawer *KeyBindingsDrawer
+	branchDrawer      *BranchDrawer
+	bookmarksDrawer   *BookmarksDrawer
+	profilesDrawer    *ProfilesDrawer
+	containers        []Container
+	bars              []Container
+	drawers           []Container
+	screenKeyboard    *ScreenKeyboard
+	toastManager      *ToastManager
 }
 
 var cmdFn func(interface{})
@@ -46,10 +51,16 @@ func New(fn func(interface{})) *UI {
 	ui.settingsDrawer = NewSettingsDrawer()
 	ui.variantPicker = NewVariantPicker()
 	ui.textDrawer = NewTextDrawer() // contents are added when shown
+	ui.keyBindingsDrawer = NewKeyBindingsDrawer()
+	ui.branchDrawer = NewBranchDrawer()
+	ui.bookmarksDrawer = NewBookmarksDrawer()
+	ui.profilesDrawer = NewProfilesDrawer()
+	ui.screenKeyboard = NewScreenKeyboard()
+	screenKeyboard = ui.screenKeyboard
 
 	ui.bars = []Container{ui.toolbar, ui.statusbar, ui.fabbar}
-	ui.drawers = []Container{ui.navDrawer, ui.settingsDrawer, ui.variantPicker, ui.textDrawer}
-	ui.containers = []Container{ui.toolbar, ui.statusbar, ui.fabbar, ui.navDrawer, ui.settingsDrawer, ui.variantPicker, ui.textDrawer}
+	ui.drawers = []Container{ui.navDrawer, ui.settingsDrawer, ui.variantPicker, ui.textDrawer, ui.keyBindingsDrawer, ui.branchDrawer, ui.bookmarksDrawer, ui.profilesDrawer}
+	ui.containers = []Container{ui.toolbar, ui.statusbar, ui.fabbar, ui.navDrawer, ui.settingsDrawer, ui.variantPicker, ui.textDrawer, ui.keyBindingsDrawer, ui.branchDrawer, ui.bookmarksDrawer, ui.profilesDrawer}
 
 	return ui
 }
@@ -70,6 +81,10 @@ func New(fn func(interface{})) *UI {
 // }
 
 func (u *UI) FindContainerAt(x, y int) Container {
+	// the on-screen keyboard sits on top of everything else
+	if util.InRect(x, y, u.screenKeyboard.Rect) {
+		return u.screenKeyboard
+	}
 	for _, con := range u.containers {
 		if util.InRect(x, y, con.Rect) {
 			return con
@@ -110,6 +125,7 @@ func (u *UI) Layout(outsideWidth, outsideHeight int) (int, int) {
 	for _, con := range u.containers {
 		con.Layout(outsideWidth, outsideHeight)
 	}
+	u.screenKeyboard.Layout(outsideWidth, outsideHeight)
 	// u.toastManager.Layout(outsideWidth, outsideHeight)
 	return outsideWidth, outsideHeight
 }
@@ -128,5 +144,6 @@ func (u *UI) Draw(screen *ebiten.Image) {
 	for _, con := range u.containers {
 		con.Draw(screen)
 	}
+	u.screenKeyboard.Draw(screen)
 	u.toastManager.Draw(screen)
 }
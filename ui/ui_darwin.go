@@ -14,3 +14,16 @@ func OpenBrowserWindow(url string) {
 		}
 	}
 }
+
+// readClipboard sends the text on the clipboard
+func readClipboard(result chan<- string) {
+	out, err := exec.Command("pbpaste").Output()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	select {
+	case result <- string(out):
+	default: // a paste is already waiting
+	}
+}
@@ -14,3 +14,18 @@ func OpenBrowserWindow(url string) {
 		}
 	}
 }
+
+// readClipboard sends the text on the clipboard, trying the Wayland and then the X11 tools
+func readClipboard(result chan<- string) {
+	for _, args := range [][]string{{"wl-paste", "-n"}, {"xclip", "-o", "-selection", "clipboard"}, {"xsel", "-ob"}} {
+		out, err := exec.Command(args[0], args[1:]...).Output()
+		if err == nil {
+			select {
+			case result <- string(out):
+			default: // a paste is already waiting
+			}
+			return
+		}
+	}
+	log.Println("cannot read the clipboard, install wl-clipboard, xclip or xsel")
+}
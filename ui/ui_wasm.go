@@ -1,9 +1,35 @@
 package ui
 
 import (
+	"log"
 	"syscall/js"
 )
 
 func OpenBrowserWindow(url string) {
 	js.Global().Get("window").Call("open", url)
 }
+
+// readClipboard sends the text on the clipboard, if the browser allows it
+func readClipboard(result chan<- string) {
+	clipboard := js.Global().Get("navigator").Get("clipboard")
+	if clipboard.IsUndefined() {
+		return
+	}
+	var then, catch js.Func
+	then = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		select {
+		case result <- args[0].String():
+		default: // a paste is already waiting
+		}
+		then.Release()
+		catch.Release()
+		return nil
+	})
+	catch = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		log.Println("cannot read the clipboard:", args[0].String())
+		then.Release()
+		catch.Release()
+		return nil
+	})
+	clipboard.Call("readText").Call("then", then).Call("catch", catch)
+}
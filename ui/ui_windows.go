@@ -3,6 +3,7 @@ package ui
 import (
 	"log"
 	"os/exec"
+	"strings"
 )
 
 func OpenBrowserWindow(url string) {
@@ -14,3 +15,16 @@ func OpenBrowserWindow(url string) {
 		}
 	}
 }
+
+// readClipboard sends the text on the clipboard
+func readClipboard(result chan<- string) {
+	out, err := exec.Command("powershell", "-NoProfile", "-Command", "Get-Clipboard").Output()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	select {
+	case result <- strings.TrimRight(string(out), "\r\n"):
+	default: // a paste is already waiting
+	}
+}
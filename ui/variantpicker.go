@@ -42,6 +42,7 @@ func (u *UI) ShowVariantGroupPicker(content []string) {
 		con.Hide()
 	}
 	u.variantPicker.widgets = u.variantPicker.widgets[:0]
+	u.variantPicker.widgets = append(u.variantPicker.widgets, NewTextInput(u.variantPicker, "", "Find a variant", "Find variant", 0))
 	for _, c := range content {
 		u.variantPicker.widgets = append(u.variantPicker.widgets, NewLabel(u.variantPicker, 0, c, schriftbank.RobotoMedium24, "VariantGroup"))
 	}
@@ -49,3 +50,18 @@ func (u *UI) ShowVariantGroupPicker(content []string) {
 	u.variantPicker.LayoutWidgets()
 	u.variantPicker.Show()
 }
+
+// ShowPicker makes the variant picker visible, listing other choices; tapping one sends requestType
+func (u *UI) ShowPicker(requestType string, content []string) {
+	con := u.VisibleDrawer()
+	if con != nil {
+		con.Hide()
+	}
+	u.variantPicker.widgets = u.variantPicker.widgets[:0]
+	for _, c := range content {
+		u.variantPicker.widgets = append(u.variantPicker.widgets, NewLabel(u.variantPicker, 0, c, schriftbank.RobotoMedium24, requestType))
+	}
+	u.variantPicker.ResetScroll()
+	u.variantPicker.LayoutWidgets()
+	u.variantPicker.Show()
+}
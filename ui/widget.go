@@ -21,3 +21,11 @@ type Widget interface {
 	Draw(*ebiten.Image)
 	NotifyCallback(input.StrokeEvent)
 }
+
+// Dragger is a widget that handles drags that start on it, rather than letting its container scroll;
+// the container calls StopDrag when the drag ends
+type Dragger interface {
+	Widget
+	StartDrag(x, y int) bool
+	StopDrag()
+}